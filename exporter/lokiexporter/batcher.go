@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// logsBatcher accumulates log records across ConsumeLogs calls, and hands the batches to the exporter it wraps.
+// As the wrapped exporter is built by exporterhelper, the batches are queued and retried according to the
+// queue and retry settings.
+type logsBatcher struct {
+	component.LogsExporter
+
+	config BatchSettings
+	logger *zap.Logger
+
+	// mu guards the pending batch.
+	mu    sync.Mutex
+	batch plog.Logs
+
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+	wg           sync.WaitGroup
+}
+
+func newLogsBatcher(exp component.LogsExporter, config BatchSettings, logger *zap.Logger) *logsBatcher {
+	return &logsBatcher{
+		LogsExporter: exp,
+		config:       config,
+		logger:       logger,
+		batch:        plog.NewLogs(),
+		shutdown:     make(chan struct{}),
+	}
+}
+
+func (b *logsBatcher) Start(ctx context.Context, host component.Host) error {
+	if err := b.LogsExporter.Start(ctx, host); err != nil {
+		return err
+	}
+
+	b.wg.Add(1)
+	go b.flushOnTimeout()
+	return nil
+}
+
+// Shutdown stops the timer, then hands the pending batch to the wrapped exporter before shutting it down.
+// Only the first call has an effect, as the wrapped exporter can't be shut down twice.
+func (b *logsBatcher) Shutdown(ctx context.Context) error {
+	var err error
+	b.shutdownOnce.Do(func() {
+		close(b.shutdown)
+		b.wg.Wait()
+
+		err = multierr.Append(b.flush(ctx), b.LogsExporter.Shutdown(ctx))
+	})
+	return err
+}
+
+func (b *logsBatcher) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	b.mu.Lock()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rls.At(i).CopyTo(b.batch.ResourceLogs().AppendEmpty())
+	}
+	if b.batch.LogRecordCount() < b.config.SendBatchSize {
+		b.mu.Unlock()
+		return nil
+	}
+	batch := b.swapBatch()
+	b.mu.Unlock()
+
+	return b.LogsExporter.ConsumeLogs(ctx, batch)
+}
+
+// swapBatch returns the pending batch and replaces it with an empty one. The caller must hold mu.
+func (b *logsBatcher) swapBatch() plog.Logs {
+	batch := b.batch
+	b.batch = plog.NewLogs()
+	return batch
+}
+
+// flush hands the pending batch, if any, to the wrapped exporter.
+func (b *logsBatcher) flush(ctx context.Context) error {
+	b.mu.Lock()
+	batch := b.swapBatch()
+	b.mu.Unlock()
+
+	if batch.LogRecordCount() == 0 {
+		return nil
+	}
+	return b.LogsExporter.ConsumeLogs(ctx, batch)
+}
+
+// flushOnTimeout periodically flushes the pending batch until the batcher is shut down.
+func (b *logsBatcher) flushOnTimeout() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.config.Timeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// the batch was already retried by the wrapped exporter, or dropped once its queue is full
+			if err := b.flush(context.Background()); err != nil {
+				b.logger.Error("failed to export batched log records to Loki", zap.Error(err))
+			}
+		case <-b.shutdown:
+			return
+		}
+	}
+}
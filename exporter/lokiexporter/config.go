@@ -15,14 +15,23 @@
 package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
 
 import (
+	"compress/gzip"
+	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
 )
 
+// defaultTenantHeader is the HTTP header Loki reads the tenant from.
+const defaultTenantHeader = "X-Scope-OrgID"
+
 // Config defines configuration for Loki exporter.
 type Config struct {
 	config.ExporterSettings       `mapstructure:",squash"`
@@ -50,6 +59,153 @@ type Config struct {
 	// Deprecated: [v0.57.0] use the attribute processor to add a `loki.tenant` hint.
 	// See this component's documentation for more information on how to specify the hint.
 	Tenant *Tenant `mapstructure:"tenant"`
+
+	// Batch defines how log records are accumulated across calls before being pushed to Loki.
+	Batch BatchSettings `mapstructure:"batch"`
+
+	// Provenance defines a static label identifying the collector that exported a log stream.
+	Provenance ProvenanceSettings `mapstructure:"provenance"`
+
+	// CompressionLevel defines the gzip compression level, from 1 (best speed) to 9 (best compression),
+	// used when "compression" is "gzip". The default level of gzip is used when unset.
+	CompressionLevel int `mapstructure:"compression_level"`
+
+	// StaticTenant is the tenant that log records are sent to when they don't have a `loki.tenant` hint.
+	// It can't be named "tenant", as that key is still used by the deprecated tenant settings.
+	StaticTenant string `mapstructure:"static_tenant"`
+
+	// TenantHeader is the name of the HTTP header carrying the tenant of a push request.
+	// The "X-Scope-OrgID" header expected by Loki is used when unset.
+	TenantHeader string `mapstructure:"tenant_header"`
+
+	// TenantEndpoints maps tenants to the URL their log records are pushed to, for setups with a Loki cluster
+	// per tenant. The log records of the other tenants are pushed to the endpoint.
+	TenantEndpoints map[string]string `mapstructure:"tenant_endpoints"`
+
+	// OTLPFields defines which fields of the OTLP log records are sent as labels.
+	OTLPFields OTLPFieldsSettings `mapstructure:"otlp_fields"`
+
+	// PushFormat defines how push requests are encoded, either "protobuf" (default) or "json".
+	// It can't be named "format", as that key is still used by the deprecated line format setting.
+	PushFormat string `mapstructure:"push_format"`
+
+	// LineFormat defines how the log records are written to the lines, either "json" (default) or "otlp", which
+	// sends each log record as OTLP JSON, along with its scope and resource. A `loki.format` hint wins over it.
+	LineFormat string `mapstructure:"line_format"`
+
+	// ServiceNameAsLabel indicates whether the "service.name" resource attribute is sent as the "service_name" label.
+	ServiceNameAsLabel bool `mapstructure:"service_name_as_label"`
+
+	// K8sLabels indicates whether the "k8s.namespace.name", "k8s.pod.name" and "k8s.container.name" resource
+	// attributes are sent as the "k8s_namespace_name", "k8s_pod_name" and "k8s_container_name" labels.
+	K8sLabels bool `mapstructure:"k8s_labels"`
+
+	// LabelSanitization indicates whether the characters not allowed in Loki label names, such as the dots of
+	// "host.name", are replaced with underscores in the labels of the log streams.
+	LabelSanitization bool `mapstructure:"label_sanitization"`
+
+	// MaxLabelCount is the maximum number of labels of a log stream. The labels beyond it are dropped, starting
+	// with the "exporter" label, which doesn't come from a hint. No limit is applied when unset.
+	MaxLabelCount int `mapstructure:"max_label_count"`
+
+	// MaxLabelValueLength is the maximum length in bytes of the label values. Longer values are truncated,
+	// ending with an ellipsis. No limit is applied when unset.
+	MaxLabelValueLength int `mapstructure:"max_label_value_length"`
+
+	// MaxLineSize is the maximum size in bytes of the line of a log record. Larger lines are handled according to
+	// MaxLineSizePolicy. No limit is applied when unset.
+	MaxLineSize int `mapstructure:"max_line_size"`
+
+	// MaxLineSizePolicy defines what happens to the log records whose line is larger than MaxLineSize, either
+	// "drop" (default) or "truncate".
+	MaxLineSizePolicy string `mapstructure:"max_line_size_policy"`
+
+	// StructuredMetadata indicates whether the log attributes listed in the `loki.metadata.labels` hint are sent
+	// as the structured metadata of the entries, which requires Loki 3. When false, they stay in the lines.
+	StructuredMetadata bool `mapstructure:"structured_metadata"`
+
+	// LabelConflict defines which attributes win when a resource and a log attribute are promoted to labels of the
+	// same name, either "attribute" (default) or "resource". A `loki.label.conflict` hint on a log record wins over it.
+	LabelConflict string `mapstructure:"label_conflict"`
+
+	// LabelExpressions maps label names to OTTL expressions computing the values of the labels from every log
+	// record, e.g. `Concat([attributes["namespace"], attributes["app"]], "/")`. The values are also set as the
+	// attributes of the same name.
+	LabelExpressions map[string]string `mapstructure:"label_expressions"`
+
+	// PreserveTypes indicates whether the doubles of the JSON lines are written with a decimal point even when their
+	// value is integral, e.g. `200.0`, so that they can be told apart from the ints, e.g. `200`.
+	PreserveTypes bool `mapstructure:"preserve_types"`
+}
+
+// BatchSettings defines the configuration for batching log records across ConsumeLogs calls.
+type BatchSettings struct {
+	// Enabled indicates whether log records should be batched before being pushed. Default is false.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Timeout is the time after which a batch is pushed regardless of its size.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// SendBatchSize is the number of log records after which a batch is pushed regardless of the timeout.
+	SendBatchSize int `mapstructure:"send_batch_size"`
+}
+
+func (b *BatchSettings) validate() error {
+	if !b.Enabled {
+		return nil
+	}
+	if b.Timeout <= 0 {
+		return errors.New("\"batch.timeout\" must be positive")
+	}
+	if b.SendBatchSize <= 0 {
+		return errors.New("\"batch.send_batch_size\" must be positive")
+	}
+	return nil
+}
+
+// ProvenanceSettings defines the configuration for the label identifying the collectors a log stream went through.
+type ProvenanceSettings struct {
+	// Label is the name of the label to set on every log stream. Provenance is disabled when empty.
+	Label string `mapstructure:"label"`
+
+	// Value is the static value identifying this collector.
+	Value string `mapstructure:"value"`
+
+	// Append indicates whether Value should be appended to the value of a resource attribute with the same
+	// name as Label, if present, building a trail of the collectors a log stream went through.
+	// When false, an existing value is overwritten.
+	Append bool `mapstructure:"append"`
+}
+
+// OTLPFieldsSettings defines which fields of the OTLP log records are sent as labels, named after the
+// fields, that Loki can use as metadata.
+type OTLPFieldsSettings struct {
+	// SeverityNumber indicates whether the severity number is sent as the "severity_number" label.
+	SeverityNumber bool `mapstructure:"severity_number"`
+
+	// Flags indicates whether the flags are sent as the "flags" label.
+	Flags bool `mapstructure:"flags"`
+
+	// ObservedTimestamp indicates whether the presence of the observed timestamp is sent as the
+	// "observed_timestamp" label, either "true" or "false".
+	ObservedTimestamp bool `mapstructure:"observed_timestamp"`
+}
+
+func (o *OTLPFieldsSettings) enabled() bool {
+	return o.SeverityNumber || o.Flags || o.ObservedTimestamp
+}
+
+func (p *ProvenanceSettings) validate() error {
+	if p.Label == "" {
+		if p.Value != "" || p.Append {
+			return errors.New("\"provenance.label\" must be set when provenance is configured")
+		}
+		return nil
+	}
+	if p.Value == "" {
+		return errors.New("\"provenance.value\" must be set when \"provenance.label\" is configured")
+	}
+	return nil
 }
 
 func (c *Config) Validate() error {
@@ -57,6 +213,56 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("\"endpoint\" must be a valid URL")
 	}
 
+	if err := c.validateTenantEndpoints(); err != nil {
+		return err
+	}
+
+	if err := c.Batch.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Provenance.validate(); err != nil {
+		return err
+	}
+
+	if c.PushFormat != "" && c.PushFormat != pushFormatProtobuf && c.PushFormat != pushFormatJSON {
+		return fmt.Errorf("\"push_format\" must be one of %q or %q, but is %q", pushFormatProtobuf, pushFormatJSON, c.PushFormat)
+	}
+
+	if err := c.validateCompression(); err != nil {
+		return err
+	}
+
+	if err := c.validateMaxLineSize(); err != nil {
+		return err
+	}
+
+	if c.MaxLabelCount < 0 {
+		return errors.New("\"max_label_count\" must not be negative")
+	}
+
+	if c.MaxLabelValueLength < 0 {
+		return errors.New("\"max_label_value_length\" must not be negative")
+	}
+
+	if err := c.validateCompressionLevel(); err != nil {
+		return err
+	}
+
+	if err := c.validateLineFormat(); err != nil {
+		return err
+	}
+
+	if c.LabelConflict != "" && c.LabelConflict != labelConflictAttribute && c.LabelConflict != labelConflictResource {
+		return fmt.Errorf("\"label_conflict\" must be one of %q or %q, but is %q", labelConflictAttribute, labelConflictResource, c.LabelConflict)
+	}
+
+	if len(c.LabelExpressions) > 0 {
+		if _, err := newLabelExpressions(c.LabelExpressions, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			return err
+		}
+	}
+
 	// further validation is needed only if we are in legacy mode
 	if !c.isLegacy() {
 		return nil
@@ -79,6 +285,105 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// tenantHeader returns the name of the HTTP header carrying the tenant of a push request.
+func (c *Config) tenantHeader() string {
+	if c.TenantHeader == "" {
+		return defaultTenantHeader
+	}
+	return c.TenantHeader
+}
+
+// endpoint returns the URL the push requests of the tenant are sent to.
+func (c *Config) endpoint(tenant string) string {
+	if endpoint, ok := c.TenantEndpoints[tenant]; ok {
+		return endpoint
+	}
+	return c.Endpoint
+}
+
+func (c *Config) validateTenantEndpoints() error {
+	for tenant, endpoint := range c.TenantEndpoints {
+		if tenant == "" {
+			return errors.New("\"tenant_endpoints\" must not have an empty tenant")
+		}
+		if _, err := url.Parse(endpoint); endpoint == "" || err != nil {
+			return fmt.Errorf("\"tenant_endpoints\" must have a valid URL for the tenant %q", tenant)
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateCompression() error {
+	switch c.Compression {
+	case "", configcompression.Snappy, configcompression.Gzip, compressionNone:
+		return nil
+	}
+	return fmt.Errorf("\"compression\" must be one of %q, %q or %q, but is %q",
+		configcompression.Snappy, configcompression.Gzip, compressionNone, c.Compression)
+}
+
+// httpClientSettings returns the settings of the HTTP client sending the push requests. As the payload is
+// snappy-encoded by the exporter, the HTTP client mustn't compress it once more when snappy is configured.
+func (c *Config) httpClientSettings() confighttp.HTTPClientSettings {
+	settings := c.HTTPClientSettings
+	if settings.Compression == configcompression.Snappy {
+		settings.Compression = ""
+	}
+	return settings
+}
+
+func (c *Config) validateMaxLineSize() error {
+	if c.MaxLineSize < 0 {
+		return errors.New("\"max_line_size\" must not be negative")
+	}
+	switch c.MaxLineSizePolicy {
+	case "":
+		return nil
+	case lineSizePolicyDrop, lineSizePolicyTruncate:
+		if c.MaxLineSize == 0 {
+			return errors.New("\"max_line_size_policy\" requires \"max_line_size\" to be set")
+		}
+		return nil
+	}
+	return fmt.Errorf("\"max_line_size_policy\" must be one of %q or %q, but is %q",
+		lineSizePolicyDrop, lineSizePolicyTruncate, c.MaxLineSizePolicy)
+}
+
+// maxLineSizePolicy returns what happens to the log records whose line is larger than max_line_size.
+func (c *Config) maxLineSizePolicy() string {
+	if c.MaxLineSizePolicy == "" {
+		return lineSizePolicyDrop
+	}
+	return c.MaxLineSizePolicy
+}
+
+func (c *Config) validateCompressionLevel() error {
+	if c.CompressionLevel == 0 {
+		return nil
+	}
+	if c.Compression != configcompression.Gzip {
+		return errors.New("\"compression_level\" requires \"compression\" to be \"gzip\"")
+	}
+	if c.CompressionLevel < gzip.BestSpeed || c.CompressionLevel > gzip.BestCompression {
+		return fmt.Errorf("\"compression_level\" must be between %d and %d", gzip.BestSpeed, gzip.BestCompression)
+	}
+	return nil
+}
+
+func (c *Config) validateLineFormat() error {
+	switch c.LineFormat {
+	case "", lineFormatJSON:
+		return nil
+	case lineFormatOTLP:
+		if c.isLegacy() {
+			return fmt.Errorf("\"line_format\" can't be used with the deprecated \"format\", \"labels\", \"tenant\" and \"tenant_id\" options")
+		}
+		return nil
+	default:
+		return fmt.Errorf("\"line_format\" must be one of %q or %q, but is %q", lineFormatJSON, lineFormatOTLP, c.LineFormat)
+	}
+}
+
 func (c *Config) isLegacy() bool {
 	if c.Format != nil && *c.Format == "body" {
 		return true
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -58,6 +59,7 @@ func TestLoadConfigNewExporter(t *testing.T) {
 					ReadBufferSize:  123,
 					WriteBufferSize: 345,
 					Timeout:         time.Second * 10,
+					Compression:     configcompression.Gzip,
 				},
 				RetrySettings: exporterhelper.RetrySettings{
 					Enabled:         true,
@@ -70,6 +72,41 @@ func TestLoadConfigNewExporter(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
+				Batch: BatchSettings{
+					Enabled:       true,
+					Timeout:       5 * time.Second,
+					SendBatchSize: 500,
+				},
+				Provenance: ProvenanceSettings{
+					Label:  "collector",
+					Value:  "gateway",
+					Append: true,
+				},
+				CompressionLevel: 9,
+				StaticTenant:     "acme",
+				TenantHeader:     "X-Tenant",
+				TenantEndpoints: map[string]string{
+					"globex": "https://loki-globex:3100/loki/api/v1/push",
+				},
+				OTLPFields: OTLPFieldsSettings{
+					SeverityNumber: true,
+					Flags:          true,
+				},
+				ServiceNameAsLabel:  true,
+				K8sLabels:           true,
+				LabelSanitization:   true,
+				MaxLabelCount:       10,
+				MaxLabelValueLength: 128,
+				PushFormat:          "json",
+				LineFormat:          "otlp",
+				MaxLineSize:         65536,
+				MaxLineSizePolicy:   "truncate",
+				StructuredMetadata:  true,
+				LabelConflict:       "resource",
+				LabelExpressions: map[string]string{
+					"workload": `Concat([attributes["namespace"], attributes["app"]], "/")`,
+				},
+				PreserveTypes: true,
 			},
 		},
 	}
@@ -161,6 +198,446 @@ func TestIsLegacy(t *testing.T) {
 	}
 }
 
+func TestBatchSettingsValidate(t *testing.T) {
+	testCases := []struct {
+		desc string
+		cfg  BatchSettings
+		err  string
+	}{
+		{
+			desc: "disabled",
+			cfg:  BatchSettings{},
+		},
+		{
+			desc: "valid",
+			cfg:  BatchSettings{Enabled: true, Timeout: time.Second, SendBatchSize: 10},
+		},
+		{
+			desc: "invalid timeout",
+			cfg:  BatchSettings{Enabled: true, SendBatchSize: 10},
+			err:  "\"batch.timeout\" must be positive",
+		},
+		{
+			desc: "invalid batch size",
+			cfg:  BatchSettings{Enabled: true, Timeout: time.Second},
+			err:  "\"batch.send_batch_size\" must be positive",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := tC.cfg.validate()
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestProvenanceSettingsValidate(t *testing.T) {
+	testCases := []struct {
+		desc string
+		cfg  ProvenanceSettings
+		err  string
+	}{
+		{
+			desc: "disabled",
+			cfg:  ProvenanceSettings{},
+		},
+		{
+			desc: "valid",
+			cfg:  ProvenanceSettings{Label: "collector", Value: "gateway", Append: true},
+		},
+		{
+			desc: "missing label",
+			cfg:  ProvenanceSettings{Value: "gateway"},
+			err:  "\"provenance.label\" must be set when provenance is configured",
+		},
+		{
+			desc: "missing value",
+			cfg:  ProvenanceSettings{Label: "collector"},
+			err:  "\"provenance.value\" must be set when \"provenance.label\" is configured",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := tC.cfg.validate()
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestCompressionValidate(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		compression configcompression.CompressionType
+		err         string
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc:        "snappy",
+			compression: configcompression.Snappy,
+		},
+		{
+			desc:        "gzip",
+			compression: configcompression.Gzip,
+		},
+		{
+			desc:        "none",
+			compression: compressionNone,
+		},
+		{
+			desc:        "unsupported",
+			compression: configcompression.Zstd,
+			err:         "\"compression\" must be one of \"snappy\", \"gzip\" or \"none\", but is \"zstd\"",
+		},
+		{
+			desc:        "unknown",
+			compression: "lz4",
+			err:         "\"compression\" must be one of \"snappy\", \"gzip\" or \"none\", but is \"lz4\"",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint:    "http://loki:3100/loki/api/v1/push",
+					Compression: tC.compression,
+				},
+			}
+			err := cfg.Validate()
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestPushFormatValidate(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		pushFormat string
+		err        string
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc:       "protobuf",
+			pushFormat: "protobuf",
+		},
+		{
+			desc:       "json",
+			pushFormat: "json",
+		},
+		{
+			desc:       "unknown",
+			pushFormat: "logfmt",
+			err:        "\"push_format\" must be one of \"protobuf\" or \"json\", but is \"logfmt\"",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://loki:3100/loki/api/v1/push"},
+				PushFormat:         tC.pushFormat,
+			}
+			err := cfg.Validate()
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestLabelConflictValidate(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		labelConflict string
+		err           string
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc:          "attribute",
+			labelConflict: "attribute",
+		},
+		{
+			desc:          "resource",
+			labelConflict: "resource",
+		},
+		{
+			desc:          "unknown",
+			labelConflict: "scope",
+			err:           "\"label_conflict\" must be one of \"attribute\" or \"resource\", but is \"scope\"",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://loki:3100/loki/api/v1/push"},
+				LabelConflict:      tC.labelConflict,
+			}
+			err := cfg.Validate()
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestLabelExpressionsValidate(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		expressions map[string]string
+		err         string
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc: "valid",
+			expressions: map[string]string{
+				"workload": `Concat([attributes["namespace"], attributes["app"]], "/")`,
+			},
+		},
+		{
+			desc: "empty label name",
+			expressions: map[string]string{
+				"": `attributes["app"]`,
+			},
+			err: "\"label_expressions\" must not contain an empty label name",
+		},
+		{
+			desc: "unknown function",
+			expressions: map[string]string{
+				"workload": `Join(attributes["app"])`,
+			},
+			err: "invalid \"label_expressions\": invalid argument at position 1 undefined function Join",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://loki:3100/loki/api/v1/push"},
+				LabelExpressions:   tC.expressions,
+			}
+			err := cfg.Validate()
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestLineFormatValidate(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		lineFormat string
+		format     *string
+		labels     *LabelsConfig
+		err        string
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc:       "json",
+			lineFormat: "json",
+		},
+		{
+			desc:       "otlp",
+			lineFormat: "otlp",
+			format:     stringp("json"),
+		},
+		{
+			desc:       "otlp with deprecated body format",
+			lineFormat: "otlp",
+			format:     stringp("body"),
+			err:        "\"line_format\" can't be used with the deprecated \"format\", \"labels\", \"tenant\" and \"tenant_id\" options",
+		},
+		{
+			desc:       "otlp with deprecated labels",
+			lineFormat: "otlp",
+			labels:     &LabelsConfig{Attributes: map[string]string{"http.status": "status"}},
+			err:        "\"line_format\" can't be used with the deprecated \"format\", \"labels\", \"tenant\" and \"tenant_id\" options",
+		},
+		{
+			desc:       "unknown",
+			lineFormat: "logfmt",
+			err:        "\"line_format\" must be one of \"json\" or \"otlp\", but is \"logfmt\"",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://loki:3100/loki/api/v1/push"},
+				LineFormat:         tC.lineFormat,
+				Format:             tC.format,
+				Labels:             tC.labels,
+			}
+			err := cfg.Validate()
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestMaxLineSizeValidate(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		maxLineSize int
+		policy      string
+		err         string
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc:        "default policy",
+			maxLineSize: 1024,
+		},
+		{
+			desc:        "truncate",
+			maxLineSize: 1024,
+			policy:      "truncate",
+		},
+		{
+			desc:        "negative size",
+			maxLineSize: -1,
+			err:         "\"max_line_size\" must not be negative",
+		},
+		{
+			desc:   "policy without size",
+			policy: "drop",
+			err:    "\"max_line_size_policy\" requires \"max_line_size\" to be set",
+		},
+		{
+			desc:        "unknown policy",
+			maxLineSize: 1024,
+			policy:      "split",
+			err:         "\"max_line_size_policy\" must be one of \"drop\" or \"truncate\", but is \"split\"",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://loki:3100/loki/api/v1/push"},
+				MaxLineSize:        tC.maxLineSize,
+				MaxLineSizePolicy:  tC.policy,
+			}
+			err := cfg.Validate()
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestLabelLimitsValidate(t *testing.T) {
+	cfg := &Config{
+		HTTPClientSettings:  confighttp.HTTPClientSettings{Endpoint: "http://loki:3100/loki/api/v1/push"},
+		MaxLabelCount:       15,
+		MaxLabelValueLength: 2048,
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxLabelCount = -1
+	assert.EqualError(t, cfg.Validate(), "\"max_label_count\" must not be negative")
+
+	cfg.MaxLabelCount = 0
+	cfg.MaxLabelValueLength = -1
+	assert.EqualError(t, cfg.Validate(), "\"max_label_value_length\" must not be negative")
+}
+
+func TestTenantEndpointsValidate(t *testing.T) {
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://loki:3100/loki/api/v1/push"},
+		TenantEndpoints:    map[string]string{"acme": "http://loki-acme:3100/loki/api/v1/push"},
+	}
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, "http://loki-acme:3100/loki/api/v1/push", cfg.endpoint("acme"))
+	assert.Equal(t, "http://loki:3100/loki/api/v1/push", cfg.endpoint("globex"))
+	assert.Equal(t, "http://loki:3100/loki/api/v1/push", cfg.endpoint(""))
+
+	cfg.TenantEndpoints = map[string]string{"acme": ""}
+	assert.EqualError(t, cfg.Validate(), "\"tenant_endpoints\" must have a valid URL for the tenant \"acme\"")
+
+	cfg.TenantEndpoints = map[string]string{"": "http://loki-acme:3100/loki/api/v1/push"}
+	assert.EqualError(t, cfg.Validate(), "\"tenant_endpoints\" must not have an empty tenant")
+}
+
+func TestCompressionLevelValidate(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		compression configcompression.CompressionType
+		level       int
+		err         string
+	}{
+		{
+			desc: "unset",
+		},
+		{
+			desc:        "valid",
+			compression: configcompression.Gzip,
+			level:       1,
+		},
+		{
+			desc:        "not gzip",
+			compression: configcompression.Zstd,
+			level:       5,
+			err:         "\"compression_level\" requires \"compression\" to be \"gzip\"",
+		},
+		{
+			desc:        "too high",
+			compression: configcompression.Gzip,
+			level:       10,
+			err:         "\"compression_level\" must be between 1 and 9",
+		},
+		{
+			desc:        "negative",
+			compression: configcompression.Gzip,
+			level:       -1,
+			err:         "\"compression_level\" must be between 1 and 9",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{Compression: tC.compression},
+				CompressionLevel:   tC.level,
+			}
+			err := cfg.validateCompressionLevel()
+			if tC.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tC.err)
+			}
+		})
+	}
+}
+
 func stringp(str string) *string {
 	return &str
 }
@@ -16,7 +16,9 @@ package lokiexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"context"
+	"sync"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 )
@@ -27,8 +29,15 @@ const (
 	stability = component.StabilityLevelBeta
 )
 
+var once sync.Once
+
 // NewFactory creates a factory for the legacy Loki exporter.
 func NewFactory() component.ExporterFactory {
+	once.Do(func() {
+		// TODO: as with other -contrib factories registering metrics, this is causing the error being ignored
+		_ = view.Register(MetricViews()...)
+	})
+
 	return component.NewExporterFactory(
 		typeStr,
 		createDefaultLegacyConfig,
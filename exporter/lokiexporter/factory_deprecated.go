@@ -36,6 +36,10 @@ func createDefaultLegacyConfig() config.Exporter {
 		},
 		RetrySettings: exporterhelper.NewDefaultRetrySettings(),
 		QueueSettings: exporterhelper.NewDefaultQueueSettings(),
+		Batch: BatchSettings{
+			Timeout:       time.Second,
+			SendBatchSize: 8192,
+		},
 	}
 }
 
@@ -22,9 +22,12 @@ import (
 )
 
 func createNextLogsExporter(ctx context.Context, set component.ExporterCreateSettings, cfg *Config) (component.LogsExporter, error) {
-	exp := newNextExporter(cfg, set.TelemetrySettings)
+	exp, err := newNextExporter(cfg, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
 
-	return exporterhelper.NewLogsExporter(
+	logsExporter, err := exporterhelper.NewLogsExporter(
 		ctx,
 		set,
 		cfg,
@@ -36,4 +39,10 @@ func createNextLogsExporter(ctx context.Context, set component.ExporterCreateSet
 		exporterhelper.WithStart(exp.start),
 		exporterhelper.WithShutdown(exp.stop),
 	)
+	if err != nil || !cfg.Batch.Enabled {
+		return logsExporter, err
+	}
+
+	// batching happens in front of exporterhelper, so that the batches go through the queue and are retried
+	return newLogsBatcher(logsExporter, cfg.Batch, set.Logger), nil
 }
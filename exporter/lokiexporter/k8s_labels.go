@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+// k8sAttributes are the resource attributes identifying the Kubernetes workload that are sent as labels.
+var k8sAttributes = []string{
+	conventions.AttributeK8SNamespaceName,
+	conventions.AttributeK8SPodName,
+	conventions.AttributeK8SContainerName,
+}
+
+// addK8sLabels also sets the Kubernetes namespace, pod and container resource attributes of ld under their
+// sanitized names, e.g. "k8s_namespace_name", which are hinted as labels.
+func addK8sLabels(ld plog.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		attrs := rls.At(i).Resource().Attributes()
+		var labels []string
+		for _, name := range k8sAttributes {
+			val, ok := attrs.Get(name)
+			if !ok {
+				continue
+			}
+			label := sanitizeLabelName(name)
+			attrs.PutStr(label, val.AsString())
+			labels = append(labels, label)
+		}
+		if len(labels) == 0 {
+			continue
+		}
+
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			logs := sls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				for _, label := range labels {
+					addLabelHint(logs.At(k).Attributes(), hintResources, label)
+				}
+			}
+		}
+	}
+}
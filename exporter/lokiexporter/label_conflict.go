@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	// hintConflict is the attribute hinting which of the resource or the log attributes win when both are promoted
+	// to labels of the same name, as read by the Loki translator.
+	hintConflict = "loki.label.conflict"
+
+	// labelConflictResource makes the resource attributes win.
+	labelConflictResource = "resource"
+
+	// labelConflictAttribute makes the log attributes win, which is the behavior of the translator without a hint.
+	labelConflictAttribute = "attribute"
+)
+
+// setLabelConflict hints on every log record of ld without a conflict hint that the given source wins when a
+// resource and a log attribute are promoted to labels of the same name.
+func setLabelConflict(ld plog.Logs, source string) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			logs := sls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				attrs := logs.At(k).Attributes()
+				if _, ok := attrs.Get(hintConflict); !ok {
+					attrs.PutStr(hintConflict, source)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllogs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// labelExpressions computes the values of labels from OTTL expressions evaluated against every log record.
+type labelExpressions struct {
+	labels     []string
+	statements []*ottl.Statement[ottllogs.TransformContext]
+}
+
+// newLabelExpressions compiles the expressions, keyed by label name. OTTL only parses statements, so every
+// expression is turned into a statement setting the attribute of the same name as the label to its value.
+func newLabelExpressions(expressions map[string]string, settings component.TelemetrySettings) (*labelExpressions, error) {
+	labels := make([]string, 0, len(expressions))
+	for label := range expressions {
+		if label == "" {
+			return nil, fmt.Errorf("\"label_expressions\" must not contain an empty label name")
+		}
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	statements := make([]string, 0, len(labels))
+	for _, label := range labels {
+		statements = append(statements, fmt.Sprintf("set(attributes[%q], %s)", label, expressions[label]))
+	}
+	parser := ottllogs.NewParser(labelExpressionFunctions(), settings)
+	parsed, err := parser.ParseStatements(statements)
+	if err != nil {
+		return nil, fmt.Errorf("invalid \"label_expressions\": %w", err)
+	}
+
+	return &labelExpressions{labels: labels, statements: parsed}, nil
+}
+
+// apply sets the values of the expressions as attributes of every log record of ld, along with the hint that
+// these attributes should be promoted to labels. An expression that fails to be evaluated, or that has no value,
+// e.g. as it refers to a missing attribute, doesn't set its label.
+func (e *labelExpressions) apply(ld plog.Logs, logger *zap.Logger) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		resource := rls.At(i).Resource()
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			scope := sls.At(j).Scope()
+			logs := sls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				ctx := ottllogs.NewTransformContext(lr, scope, resource)
+				for n, statement := range e.statements {
+					label := e.labels[n]
+					if _, _, err := statement.Execute(ctx); err != nil {
+						logger.Debug("failed to evaluate the label expression", zap.String("label", label), zap.Error(err))
+						continue
+					}
+					if _, ok := lr.Attributes().Get(label); ok {
+						addLabelHint(lr.Attributes(), hintAttributes, label)
+					}
+				}
+			}
+		}
+	}
+}
+
+// labelExpressionFunctions returns the functions that can be invoked in the expressions.
+func labelExpressionFunctions() map[string]interface{} {
+	return map[string]interface{}{
+		"Concat":  ottlfuncs.Concat[ottllogs.TransformContext],
+		"Int":     ottlfuncs.Int[ottllogs.TransformContext],
+		"Mask":    ottlfuncs.Mask[ottllogs.TransformContext],
+		"SpanID":  ottlfuncs.SpanID[ottllogs.TransformContext],
+		"TraceID": ottlfuncs.TraceID[ottllogs.TransformContext],
+		"set":     ottlfuncs.Set[ottllogs.TransformContext],
+	}
+}
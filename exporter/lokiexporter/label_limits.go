@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"sort"
+
+	"github.com/prometheus/common/model"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+const (
+	// exporterLabel is set on every stream by the translator, rather than from a hint.
+	exporterLabel = "exporter"
+
+	labelValueEllipsis = "..."
+)
+
+// limitLabels enforces the max label count and value length on the streams of the push request, either of them
+// being unlimited when not positive. When a stream has more than maxCount labels, the exporter label is dropped
+// first, as it doesn't come from a hint, followed by the labels whose names sort last. The label values longer than
+// maxValueLength bytes are truncated, ending with an ellipsis.
+func limitLabels(request *loki.PushRequest, maxCount, maxValueLength int, logger *zap.Logger) error {
+	return rewriteLabels(request, func(labels map[string]string) model.LabelSet {
+		names := make([]string, 0, len(labels))
+		for name := range labels {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if names[i] == exporterLabel || names[j] == exporterLabel {
+				return names[j] == exporterLabel
+			}
+			return names[i] < names[j]
+		})
+
+		if maxCount > 0 && len(names) > maxCount {
+			logger.Debug("dropping labels exceeding the max label count",
+				zap.Strings("labels", names[maxCount:]),
+				zap.Int("max_label_count", maxCount),
+			)
+			names = names[:maxCount]
+		}
+
+		limited := make(model.LabelSet, len(names))
+		for _, name := range names {
+			value := labels[name]
+			if maxValueLength > 0 && len(value) > maxValueLength {
+				value = truncateLabelValue(value, maxValueLength)
+			}
+			limited[model.LabelName(name)] = model.LabelValue(value)
+		}
+		return limited
+	})
+}
+
+// truncateLabelValue cuts the value to at most maxLength bytes, replacing its end with an ellipsis.
+func truncateLabelValue(value string, maxLength int) string {
+	if maxLength <= len(labelValueEllipsis) {
+		return truncateString(value, maxLength)
+	}
+	return truncateString(value, maxLength-len(labelValueEllipsis)) + labelValueEllipsis
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+func TestLimitLabels(t *testing.T) {
+	entry := func(line string) logproto.Entry {
+		return logproto.Entry{Timestamp: time.Unix(0, 1670000000000000000), Line: line}
+	}
+	testCases := []struct {
+		desc           string
+		maxCount       int
+		maxValueLength int
+		streams        []logproto.Stream
+		expected       []logproto.Stream
+		expectedLogs   int
+	}{
+		{
+			desc:     "within the limits",
+			maxCount: 3,
+			streams: []logproto.Stream{
+				{Labels: `{exporter="OTLP", host.name="guarana", level="info"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+			expected: []logproto.Stream{
+				{Labels: `{exporter="OTLP", host.name="guarana", level="info"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+		},
+		{
+			desc:     "the exporter label is dropped first",
+			maxCount: 2,
+			streams: []logproto.Stream{
+				{Labels: `{exporter="OTLP", host.name="guarana", level="info"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+			expected: []logproto.Stream{
+				{Labels: `{host.name="guarana", level="info"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+			expectedLogs: 1,
+		},
+		{
+			desc:     "the labels sorting last are dropped next",
+			maxCount: 1,
+			streams: []logproto.Stream{
+				{Labels: `{exporter="OTLP", host.name="guarana", level="info"}`, Entries: []logproto.Entry{entry("a")}},
+				{Labels: `{exporter="OTLP", host.name="guarana", level="error"}`, Entries: []logproto.Entry{entry("b")}},
+			},
+			expected: []logproto.Stream{
+				{Labels: `{host.name="guarana"}`, Entries: []logproto.Entry{entry("a"), entry("b")}},
+			},
+			expectedLogs: 2,
+		},
+		{
+			desc:           "long values are truncated",
+			maxValueLength: 10,
+			streams: []logproto.Stream{
+				{Labels: `{exporter="OTLP", k8s.pod.name="checkout-5d8f9c7b6-x2x7z"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+			expected: []logproto.Stream{
+				{Labels: `{exporter="OTLP", k8s.pod.name="checkou..."}`, Entries: []logproto.Entry{entry("a")}},
+			},
+		},
+		{
+			desc:           "multi-byte characters aren't split",
+			maxValueLength: 5,
+			streams: []logproto.Stream{
+				{Labels: `{city="Zürich"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+			expected: []logproto.Stream{
+				{Labels: `{city="Z..."}`, Entries: []logproto.Entry{entry("a")}},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			request := loki.PushRequest{
+				PushRequest: &logproto.PushRequest{Streams: tC.streams},
+				Report:      &loki.PushReport{},
+			}
+
+			err := limitLabels(&request, tC.maxCount, tC.maxValueLength, zap.New(core))
+			require.NoError(t, err)
+			assert.Equal(t, tC.expected, request.Streams)
+			assert.Equal(t, tC.expectedLogs, logs.Len())
+		})
+	}
+}
+
+func TestTruncateLabelValue(t *testing.T) {
+	assert.Equal(t, "abcdefg...", truncateLabelValue(strings.Repeat("abcdefghij", 2), 10))
+	assert.Equal(t, "ab", truncateLabelValue("abcdef", 2))
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+// sanitizeLabelName replaces the characters not allowed in Loki label names, which must match
+// `[a-zA-Z_][a-zA-Z0-9_]*`, with underscores, e.g. `host.name` becomes `host_name`.
+func sanitizeLabelName(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	for i, r := range name {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9' && i > 0) {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// sanitizeLabels sanitizes the label names of the streams of the push request. When several labels of a stream are
+// sanitized into the same name, the label whose name was already valid is kept, otherwise the one whose name sorts
+// first, and the others are dropped with a warning. The streams ending up with identical labels are merged.
+func sanitizeLabels(request *loki.PushRequest, logger *zap.Logger) error {
+	return rewriteLabels(request, func(labels map[string]string) model.LabelSet {
+		names := make([]string, 0, len(labels))
+		for name := range labels {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			validI, validJ := names[i] == sanitizeLabelName(names[i]), names[j] == sanitizeLabelName(names[j])
+			if validI != validJ {
+				return validI
+			}
+			return names[i] < names[j]
+		})
+
+		sanitized := make(model.LabelSet, len(labels))
+		for _, name := range names {
+			sanitizedName := model.LabelName(sanitizeLabelName(name))
+			if _, exists := sanitized[sanitizedName]; exists {
+				logger.Warn("dropping label colliding with another label once sanitized",
+					zap.String("label", name),
+					zap.String("sanitized_label", string(sanitizedName)),
+				)
+				continue
+			}
+			sanitized[sanitizedName] = model.LabelValue(labels[name])
+		}
+		return sanitized
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+func TestSanitizeLabelName(t *testing.T) {
+	testCases := map[string]string{
+		"exporter":         "exporter",
+		"host.name":        "host_name",
+		"http.status_code": "http_status_code",
+		"k8s-pod":          "k8s_pod",
+		"1st":              "_st",
+		"_private":         "_private",
+		"héllo":            "h_llo",
+	}
+	for name, expected := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, sanitizeLabelName(name))
+		})
+	}
+}
+
+func TestSanitizeLabels(t *testing.T) {
+	entry := func(line string) logproto.Entry {
+		return logproto.Entry{Timestamp: time.Unix(0, 1670000000000000000), Line: line}
+	}
+	testCases := []struct {
+		desc             string
+		streams          []logproto.Stream
+		expected         []logproto.Stream
+		expectedWarnings int
+	}{
+		{
+			desc: "invalid characters",
+			streams: []logproto.Stream{
+				{Labels: `{exporter="OTLP", host.name="guarana"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+			expected: []logproto.Stream{
+				{Labels: `{exporter="OTLP", host_name="guarana"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+		},
+		{
+			desc: "collision keeps the valid label",
+			streams: []logproto.Stream{
+				{Labels: `{a.b="dotted", a_b="valid", exporter="OTLP"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+			expected: []logproto.Stream{
+				{Labels: `{a_b="valid", exporter="OTLP"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+			expectedWarnings: 1,
+		},
+		{
+			desc: "collision keeps the first label",
+			streams: []logproto.Stream{
+				{Labels: `{a-b="dashed", a.b="dotted", exporter="OTLP"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+			expected: []logproto.Stream{
+				{Labels: `{a_b="dashed", exporter="OTLP"}`, Entries: []logproto.Entry{entry("a")}},
+			},
+			expectedWarnings: 1,
+		},
+		{
+			desc: "streams with identical labels are merged",
+			streams: []logproto.Stream{
+				{Labels: `{exporter="OTLP", host.name="guarana"}`, Entries: []logproto.Entry{entry("a")}},
+				{Labels: `{exporter="OTLP", host_name="guarana"}`, Entries: []logproto.Entry{entry("b")}},
+				{Labels: `{exporter="OTLP", host_name="maracuja"}`, Entries: []logproto.Entry{entry("c")}},
+			},
+			expected: []logproto.Stream{
+				{Labels: `{exporter="OTLP", host_name="guarana"}`, Entries: []logproto.Entry{entry("a"), entry("b")}},
+				{Labels: `{exporter="OTLP", host_name="maracuja"}`, Entries: []logproto.Entry{entry("c")}},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			core, logs := observer.New(zap.WarnLevel)
+			request := loki.PushRequest{
+				PushRequest: &logproto.PushRequest{Streams: tC.streams},
+				Report:      &loki.PushReport{},
+			}
+
+			err := sanitizeLabels(&request, zap.New(core))
+			require.NoError(t, err)
+			assert.Equal(t, tC.expected, request.Streams)
+			assert.Equal(t, tC.expectedWarnings, logs.Len())
+		})
+	}
+}
+
+func TestSanitizeLabelsInvalid(t *testing.T) {
+	request := loki.PushRequest{
+		PushRequest: &logproto.PushRequest{Streams: []logproto.Stream{{Labels: `exporter="OTLP"`}}},
+		Report:      &loki.PushReport{},
+	}
+	assert.Error(t, sanitizeLabels(&request, zap.NewNop()))
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/prometheus/common/model"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+// rewriteLabels replaces the labels of the streams of the push request with the ones returned by rewrite,
+// merging the streams ending up with identical labels, along with the structured metadata of their entries.
+func rewriteLabels(request *loki.PushRequest, rewrite func(labels map[string]string) model.LabelSet) error {
+	streams := make([]logproto.Stream, 0, len(request.Streams))
+	var metadata [][]model.LabelSet
+	if request.StructuredMetadata != nil {
+		metadata = make([][]model.LabelSet, 0, len(request.Streams))
+	}
+	streamIndex := make(map[string]int, len(request.Streams))
+	for i, stream := range request.Streams {
+		labels, err := parseLabels(stream.Labels)
+		if err != nil {
+			return err
+		}
+
+		stream.Labels = rewrite(labels).String()
+		if j, ok := streamIndex[stream.Labels]; ok {
+			streams[j].Entries = append(streams[j].Entries, stream.Entries...)
+			if metadata != nil {
+				metadata[j] = append(metadata[j], request.StructuredMetadata[i]...)
+			}
+			continue
+		}
+		streamIndex[stream.Labels] = len(streams)
+		streams = append(streams, stream)
+		if metadata != nil {
+			metadata = append(metadata, request.StructuredMetadata[i])
+		}
+	}
+	request.Streams = streams
+	request.StructuredMetadata = metadata
+	return nil
+}
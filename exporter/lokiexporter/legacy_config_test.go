@@ -72,6 +72,10 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
+				Batch: BatchSettings{
+					Timeout:       time.Second,
+					SendBatchSize: 8192,
+				},
 				TenantID: stringp("example"),
 				Labels: &LabelsConfig{
 					Attributes: map[string]string{
@@ -119,6 +123,10 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 10,
 					QueueSize:    5000,
 				},
+				Batch: BatchSettings{
+					Timeout:       time.Second,
+					SendBatchSize: 8192,
+				},
 				TenantID: stringp("example"),
 				Labels: &LabelsConfig{
 					RecordAttributes: map[string]string{
@@ -17,6 +17,7 @@ package lokiexporter // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -32,6 +33,7 @@ import (
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -117,7 +119,11 @@ func (l *lokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
 		return consumererror.NewPermanent(fmt.Errorf("failed to transform logs into Loki log streams"))
 	}
 
-	buf, err := encode(pushReq)
+	buf, err := encode(pushReq, l.config.Compression)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	buf, contentEncoding, err := compressWithLevel(buf, l.config)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -131,6 +137,9 @@ func (l *lokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
 		req.Header.Set(k, v)
 	}
 	req.Header.Set("Content-Type", "application/x-protobuf")
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
 
 	tenant, err := l.tenantSource.GetTenant(ctx, ld)
 	if err != nil {
@@ -138,7 +147,7 @@ func (l *lokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
 	}
 
 	if len(tenant) > 0 {
-		req.Header.Set("X-Scope-OrgID", tenant)
+		req.Header.Set(l.config.tenantHeader(), tenant)
 	}
 
 	resp, err := l.client.Do(req)
@@ -172,17 +181,55 @@ func (l *lokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
 	return nil
 }
 
-func encode(pb proto.Message) ([]byte, error) {
+// compressionNone mirrors the unexported "none" compression type of confighttp. Besides
+// disabling the compression of the HTTP request, it disables the snappy encoding of the payload.
+// Any other supported compression type keeps the snappy encoding expected by Loki.
+const compressionNone configcompression.CompressionType = "none"
+
+func encode(pb proto.Message, compression configcompression.CompressionType) ([]byte, error) {
 	buf, err := proto.Marshal(pb)
 	if err != nil {
 		return nil, err
 	}
+	if compression == compressionNone {
+		return buf, nil
+	}
 	buf = snappy.Encode(nil, buf)
 	return buf, nil
 }
 
+// compressWithLevel compresses the body with gzip when a compression level is configured, as the HTTP client would
+// compress it with the default level, and returns the content encoding to set. Otherwise, the body is returned as is,
+// leaving the compression to the HTTP client.
+func compressWithLevel(buf []byte, cfg *Config) ([]byte, string, error) {
+	if cfg.Compression != configcompression.Gzip || cfg.CompressionLevel == 0 {
+		return buf, "", nil
+	}
+	buf, err := gzipEncode(buf, cfg.CompressionLevel)
+	if err != nil {
+		return nil, "", err
+	}
+	return buf, "gzip", nil
+}
+
+func gzipEncode(buf []byte, level int) ([]byte, error) {
+	var b bytes.Buffer
+	w, err := gzip.NewWriterLevel(&b, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(buf); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
 func (l *lokiExporter) start(_ context.Context, host component.Host) (err error) {
-	client, err := l.config.HTTPClientSettings.ToClient(host, l.settings)
+	clientSettings := l.config.httpClientSettings()
+	client, err := clientSettings.ToClient(host, l.settings)
 	if err != nil {
 		return err
 	}
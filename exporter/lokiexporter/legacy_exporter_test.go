@@ -15,6 +15,8 @@
 package lokiexporter
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -32,6 +34,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -272,6 +275,49 @@ func TestExporter_pushLogData(t *testing.T) {
 	}
 }
 
+func TestExporter_pushLogDataWithGzipCompressionLevel(t *testing.T) {
+	var contentEncoding string
+	var gzipExtraFlags byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentEncoding = r.Header.Get("Content-Encoding")
+		compressed, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Greater(t, len(compressed), 8)
+		// the XFL byte of the gzip header is set to 2 when the best compression level is used
+		gzipExtraFlags = compressed[8]
+
+		gr, err := gzip.NewReader(bytes.NewReader(compressed))
+		require.NoError(t, err)
+		encPayload, err := io.ReadAll(gr)
+		require.NoError(t, err)
+		_, err = snappy.Decode(nil, encPayload)
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint:    server.URL,
+			Compression: configcompression.Gzip,
+		},
+		CompressionLevel: gzip.BestCompression,
+		Labels: &LabelsConfig{
+			Attributes: map[string]string{"severity": "severity"},
+		},
+	}
+	require.NoError(t, config.Validate())
+
+	exp := newLegacyExporter(config, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+
+	logs := plog.NewLogs()
+	appendTestLogData(logs, 1, map[string]interface{}{"severity": "debug"})
+	require.NoError(t, exp.pushLogData(context.Background(), logs))
+
+	assert.Equal(t, "gzip", contentEncoding)
+	assert.Equal(t, byte(2), gzipExtraFlags)
+}
+
 func TestTenantSource(t *testing.T) {
 	testCases := []struct {
 		desc    string
@@ -621,7 +667,7 @@ func TestExporter_encode(t *testing.T) {
 			Streams: []logproto.Stream{stream},
 		}
 
-		req, err := encode(pr)
+		req, err := encode(pr, "")
 		require.NoError(t, err)
 		_, err = snappy.Decode(nil, req)
 		require.NoError(t, err)
@@ -632,7 +678,7 @@ func TestExporter_encode(t *testing.T) {
 			Foo: "Bar",
 		}
 
-		req, err := encode(p)
+		req, err := encode(p, "")
 		require.Error(t, err)
 		require.Nil(t, req)
 	})
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	// hintFormat is the attribute hinting the format of the lines, as read by the Loki translator.
+	hintFormat = "loki.format"
+
+	// lineFormatJSON is the default format, sending the body and the attributes of each log record as JSON.
+	lineFormatJSON = "json"
+
+	// lineFormatOTLP is the format sending each log record as OTLP JSON, along with its scope and resource.
+	lineFormatOTLP = "otlp"
+)
+
+// setLineFormat hints on every log record of ld without a format hint, either on itself or on its resource,
+// that its line should use the given format.
+func setLineFormat(ld plog.Logs, format string) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		if _, ok := rls.At(i).Resource().Attributes().Get(hintFormat); ok {
+			continue
+		}
+
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			logs := sls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				attrs := logs.At(k).Attributes()
+				if _, ok := attrs.Get(hintFormat); !ok {
+					attrs.PutStr(hintFormat, format)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"unicode/utf8"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+const (
+	lineSizePolicyDrop     = "drop"
+	lineSizePolicyTruncate = "truncate"
+)
+
+// applyLineSizePolicy applies the policy to the entries of the push request whose line is larger than maxSize bytes,
+// either truncating their line or dropping them, in which case they are counted as dropped in the report.
+// The streams left without entries are removed. It returns the number of oversized lines.
+func applyLineSizePolicy(request *loki.PushRequest, maxSize int, policy string) int {
+	oversized := 0
+	streams := request.Streams[:0]
+	var metadata [][]model.LabelSet
+	if request.StructuredMetadata != nil {
+		metadata = request.StructuredMetadata[:0]
+	}
+	for i, stream := range request.Streams {
+		entries := stream.Entries[:0]
+		var entriesMetadata []model.LabelSet
+		if metadata != nil {
+			entriesMetadata = request.StructuredMetadata[i][:0]
+		}
+		for j, entry := range stream.Entries {
+			if len(entry.Line) > maxSize {
+				oversized++
+				if policy != lineSizePolicyTruncate {
+					request.Report.NumSubmitted--
+					request.Report.NumDropped++
+					continue
+				}
+				entry.Line = truncateString(entry.Line, maxSize)
+			}
+			entries = append(entries, entry)
+			if metadata != nil {
+				entriesMetadata = append(entriesMetadata, request.StructuredMetadata[i][j])
+			}
+		}
+		if len(entries) > 0 {
+			stream.Entries = entries
+			streams = append(streams, stream)
+			if metadata != nil {
+				metadata = append(metadata, entriesMetadata)
+			}
+		}
+	}
+	request.Streams = streams
+	request.StructuredMetadata = metadata
+	return oversized
+}
+
+// truncateString cuts the string to at most maxSize bytes, without splitting a multi-byte character.
+func truncateString(s string, maxSize int) string {
+	for maxSize > 0 && !utf8.RuneStart(s[maxSize]) {
+		maxSize--
+	}
+	return s[:maxSize]
+}
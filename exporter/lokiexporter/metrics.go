@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagExporterName, _ = tag.NewKey("exporter_name")
+	tagPolicy, _       = tag.NewKey("policy")
+	tagTenant, _       = tag.NewKey("tenant")
+
+	mOversizedLines = stats.Int64("loki_exporter_oversized_lines", "Number of log lines larger than max_line_size, either truncated or dropped", stats.UnitDimensionless)
+	vOversizedLines = &view.View{
+		Name:        mOversizedLines.Name(),
+		Measure:     mOversizedLines,
+		Description: mOversizedLines.Description(),
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagExporterName, tagPolicy},
+	}
+
+	mSentStreams = stats.Int64("loki_exporter_sent_streams", "Number of log streams successfully pushed to Loki", stats.UnitDimensionless)
+	vSentStreams = &view.View{
+		Name:        mSentStreams.Name(),
+		Measure:     mSentStreams,
+		Description: mSentStreams.Description(),
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagExporterName, tagTenant},
+	}
+
+	mSentEntries = stats.Int64("loki_exporter_sent_entries", "Number of log entries successfully pushed to Loki", stats.UnitDimensionless)
+	vSentEntries = &view.View{
+		Name:        mSentEntries.Name(),
+		Measure:     mSentEntries,
+		Description: mSentEntries.Description(),
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagExporterName, tagTenant},
+	}
+
+	mSentBytes = stats.Int64("loki_exporter_sent_bytes", "Size of the bodies of the push requests successfully sent to Loki", stats.UnitBytes)
+	vSentBytes = &view.View{
+		Name:        mSentBytes.Name(),
+		Measure:     mSentBytes,
+		Description: mSentBytes.Description(),
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagExporterName, tagTenant},
+	}
+)
+
+// MetricViews return the metrics views according to given telemetry level.
+func MetricViews() []*view.View {
+	return []*view.View{vOversizedLines, vSentStreams, vSentEntries, vSentBytes}
+}
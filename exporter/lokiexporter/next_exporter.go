@@ -15,16 +15,20 @@
 package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
 
+	"github.com/grafana/loki/pkg/logproto"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -37,22 +41,86 @@ type nextLokiExporter struct {
 	settings component.TelemetrySettings
 	client   *http.Client
 	wg       sync.WaitGroup
+
+	// labelExpressions is nil unless label expressions are configured.
+	labelExpressions *labelExpressions
 }
 
-func newNextExporter(config *Config, settings component.TelemetrySettings) *nextLokiExporter {
+func newNextExporter(config *Config, settings component.TelemetrySettings) (*nextLokiExporter, error) {
 	settings.Logger.Info("using the new Loki exporter")
 
-	return &nextLokiExporter{
+	exp := &nextLokiExporter{
 		config:   config,
 		settings: settings,
 	}
+	if len(config.LabelExpressions) > 0 {
+		expressions, err := newLabelExpressions(config.LabelExpressions, settings)
+		if err != nil {
+			return nil, err
+		}
+		exp.labelExpressions = expressions
+	}
+	return exp, nil
 }
 
 func (l *nextLokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
-	requests := loki.LogsToLokiRequests(ld)
+	// the options modify the log records, so they are applied to a copy, leaving ld as is to be retried
+	out := plog.NewLogs()
+	ld.CopyTo(out)
+	if l.config.Provenance.Label != "" {
+		addProvenance(out, l.config.Provenance)
+	}
+	if l.config.OTLPFields.enabled() {
+		addOTLPFields(out, l.config.OTLPFields)
+	}
+	if l.config.ServiceNameAsLabel {
+		addServiceNameLabel(out)
+	}
+	if l.config.K8sLabels {
+		addK8sLabels(out)
+	}
+	if !l.config.StructuredMetadata {
+		removeMetadataHint(out)
+	}
+	if l.config.LineFormat == lineFormatOTLP {
+		setLineFormat(out, lineFormatOTLP)
+	}
+	if l.config.LabelConflict != "" {
+		setLabelConflict(out, l.config.LabelConflict)
+	}
+	if l.config.PreserveTypes {
+		setPreserveTypes(out)
+	}
+	if l.labelExpressions != nil {
+		l.labelExpressions.apply(out, l.settings.Logger)
+	}
+
+	requests := loki.LogsToLokiRequests(out)
 
 	var errs error
 	for tenant, request := range requests {
+		if tenant == "" {
+			tenant = l.config.StaticTenant
+		}
+		if l.config.LabelSanitization {
+			if err := sanitizeLabels(&request, l.settings.Logger); err != nil {
+				errs = multierr.Append(errs, consumererror.NewPermanent(err))
+				continue
+			}
+		}
+		if l.config.MaxLabelCount > 0 || l.config.MaxLabelValueLength > 0 {
+			if err := limitLabels(&request, l.config.MaxLabelCount, l.config.MaxLabelValueLength, l.settings.Logger); err != nil {
+				errs = multierr.Append(errs, consumererror.NewPermanent(err))
+				continue
+			}
+		}
+		if l.config.MaxLineSize > 0 {
+			oversized := l.applyLineSizePolicy(ctx, &request)
+			if oversized > 0 && len(request.Streams) == 0 {
+				// all the log records of the tenant were dropped
+				continue
+			}
+		}
 		err := l.sendPushRequest(ctx, tenant, request, ld)
 		errs = multierr.Append(errs, err)
 	}
@@ -60,6 +128,18 @@ func (l *nextLokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error
 	return errs
 }
 
+// applyLineSizePolicy truncates or drops the oversized lines of the push request, recording how many there were.
+func (l *nextLokiExporter) applyLineSizePolicy(ctx context.Context, request *loki.PushRequest) int {
+	policy := l.config.maxLineSizePolicy()
+	oversized := applyLineSizePolicy(request, l.config.MaxLineSize, policy)
+	if oversized > 0 {
+		_ = stats.RecordWithTags(ctx,
+			[]tag.Mutator{tag.Upsert(tagExporterName, l.config.ID().String()), tag.Upsert(tagPolicy, policy)},
+			mOversizedLines.M(int64(oversized)))
+	}
+	return oversized
+}
+
 func (l *nextLokiExporter) sendPushRequest(ctx context.Context, tenant string, request loki.PushRequest, ld plog.Logs) error {
 	pushReq := request.PushRequest
 	report := request.Report
@@ -74,12 +154,28 @@ func (l *nextLokiExporter) sendPushRequest(ctx context.Context, tenant string, r
 		)
 	}
 
-	buf, err := encode(pushReq)
+	var buf []byte
+	var err error
+	contentType := "application/x-protobuf"
+	switch {
+	case l.config.PushFormat == pushFormatJSON:
+		buf, err = encodeJSON(pushReq, request.StructuredMetadata)
+		contentType = "application/json"
+	case request.StructuredMetadata != nil:
+		buf, err = encodeWithStructuredMetadata(pushReq, request.StructuredMetadata, l.config.Compression)
+	default:
+		buf, err = encode(pushReq, l.config.Compression)
+	}
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	buf, contentEncoding, err := compressWithLevel(buf, l.config)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", l.config.HTTPClientSettings.Endpoint, bytes.NewReader(buf))
+	req, err := http.NewRequestWithContext(ctx, "POST", l.config.endpoint(tenant), bytes.NewReader(buf))
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -87,9 +183,12 @@ func (l *nextLokiExporter) sendPushRequest(ctx context.Context, tenant string, r
 	for k, v := range l.config.HTTPClientSettings.Headers {
 		req.Header.Set(k, v)
 	}
-	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Type", contentType)
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
 	if len(tenant) > 0 {
-		req.Header.Set("X-Scope-OrgID", tenant)
+		req.Header.Set(l.config.tenantHeader(), tenant)
 	}
 
 	resp, err := l.client.Do(req)
@@ -103,20 +202,37 @@ func (l *nextLokiExporter) sendPushRequest(ctx context.Context, tenant string, r
 	}()
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		scanner := bufio.NewScanner(io.LimitReader(resp.Body, maxErrMsgLen))
-		line := ""
-		if scanner.Scan() {
-			line = scanner.Text()
+		// Loki explains why a push was rejected in the response body, e.g. which label is invalid
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrMsgLen))
+		err = fmt.Errorf("HTTP %d %q: %s", resp.StatusCode, http.StatusText(resp.StatusCode), strings.TrimSpace(string(body)))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			// Loki is rate limiting or overloaded, so the next attempt is delayed as requested
+			delay := retryAfter(resp, l.config.RetrySettings.MaxInterval)
+			return exporterhelper.NewThrottleRetry(consumererror.NewLogs(err, ld), delay)
 		}
-		err = fmt.Errorf("HTTP %d %q: %s", resp.StatusCode, http.StatusText(resp.StatusCode), line)
 		return consumererror.NewLogs(err, ld)
 	}
 
+	l.recordSent(ctx, tenant, pushReq, len(buf))
 	return nil
 }
 
+// recordSent records the number of streams, entries and bytes pushed to the tenant.
+func (l *nextLokiExporter) recordSent(ctx context.Context, tenant string, pushReq *logproto.PushRequest, size int) {
+	entries := 0
+	for _, stream := range pushReq.Streams {
+		entries += len(stream.Entries)
+	}
+	_ = stats.RecordWithTags(ctx,
+		[]tag.Mutator{tag.Upsert(tagExporterName, l.config.ID().String()), tag.Upsert(tagTenant, tenant)},
+		mSentStreams.M(int64(len(pushReq.Streams))),
+		mSentEntries.M(int64(entries)),
+		mSentBytes.M(int64(size)))
+}
+
 func (l *nextLokiExporter) start(_ context.Context, host component.Host) (err error) {
-	client, err := l.config.HTTPClientSettings.ToClient(host, l.settings)
+	clientSettings := l.config.httpClientSettings()
+	client, err := clientSettings.ToClient(host, l.settings)
 	if err != nil {
 		return err
 	}
@@ -15,20 +15,30 @@
 package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/grafana/loki/pkg/logproto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
@@ -300,3 +310,1828 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 		})
 	}
 }
+
+func TestPushLogDataWithBatching(t *testing.T) {
+	var pushRequests []*logproto.PushRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encPayload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		decPayload, err := snappy.Decode(nil, encPayload)
+		require.NoError(t, err)
+
+		pr := &logproto.PushRequest{}
+		err = proto.Unmarshal(decPayload, pr)
+		require.NoError(t, err)
+
+		pushRequests = append(pushRequests, pr)
+	}))
+	defer ts.Close()
+
+	testCases := []struct {
+		desc          string
+		sendBatchSize int
+		// number of push requests expected right after the calls to ConsumeLogs, before shutdown
+		expectedBeforeShutdown int
+	}{
+		{
+			desc:                   "batch is pushed once the size is reached",
+			sendBatchSize:          2,
+			expectedBeforeShutdown: 1,
+		},
+		{
+			desc:                   "pending batch is pushed on shutdown",
+			sendBatchSize:          100,
+			expectedBeforeShutdown: 0,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			pushRequests = nil
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				Batch: BatchSettings{
+					Enabled:       true,
+					Timeout:       time.Hour,
+					SendBatchSize: tC.sendBatchSize,
+				},
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			// test
+			for i := 0; i < 2; i++ {
+				ld := plog.NewLogs()
+				ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(fmt.Sprintf("line %d", i))
+				err = exp.ConsumeLogs(context.Background(), ld)
+				require.NoError(t, err)
+			}
+			assert.Len(t, pushRequests, tC.expectedBeforeShutdown)
+
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+
+			// verify: both calls were combined into a single push
+			require.Len(t, pushRequests, 1)
+			require.Len(t, pushRequests[0].Streams, 1)
+			assert.Len(t, pushRequests[0].Streams[0].Entries, 2)
+		})
+	}
+}
+
+func TestPushLogDataWithBatchingRetriesFailedBatches(t *testing.T) {
+	var entries []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encPayload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		decPayload, err := snappy.Decode(nil, encPayload)
+		require.NoError(t, err)
+		pr := &logproto.PushRequest{}
+		require.NoError(t, proto.Unmarshal(decPayload, pr))
+
+		entries = append(entries, len(pr.Streams[0].Entries))
+		if len(entries) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ts.URL,
+		},
+		RetrySettings: exporterhelper.RetrySettings{
+			Enabled:         true,
+			InitialInterval: 10 * time.Millisecond,
+			MaxInterval:     10 * time.Millisecond,
+			MaxElapsedTime:  time.Minute,
+		},
+		Batch: BatchSettings{
+			Enabled:       true,
+			Timeout:       time.Hour,
+			SendBatchSize: 100,
+		},
+	}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	for i := 0; i < 2; i++ {
+		ld := plog.NewLogs()
+		ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(fmt.Sprintf("line %d", i))
+		require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+	}
+
+	// test
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	// verify: the batch pushed on shutdown was retried as a whole after the first attempt failed
+	assert.Equal(t, []int{2, 2}, entries)
+
+	// shutting down again doesn't panic
+	assert.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestPushLogDataWithProvenance(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		append        bool
+		res           map[string]interface{}
+		expectedLabel string
+	}{
+		{
+			desc:          "label is set",
+			append:        true,
+			expectedLabel: `{collector="gateway", exporter="OTLP"}`,
+		},
+		{
+			desc:   "label is appended to the trail of a previous collector",
+			append: true,
+			res: map[string]interface{}{
+				"collector": "agent",
+			},
+			expectedLabel: `{collector="agent,gateway", exporter="OTLP"}`,
+		},
+		{
+			desc:   "label is overwritten without append",
+			append: false,
+			res: map[string]interface{}{
+				"collector": "agent",
+			},
+			expectedLabel: `{collector="gateway", exporter="OTLP"}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actualPushRequest := &logproto.PushRequest{}
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encPayload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				decPayload, err := snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+
+				err = proto.Unmarshal(decPayload, actualPushRequest)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				Provenance: ProvenanceSettings{
+					Label:  "collector",
+					Value:  "gateway",
+					Append: tC.append,
+				},
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			rl := ld.ResourceLogs().AppendEmpty()
+			rl.Resource().Attributes().FromRaw(tC.res)
+			rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify
+			require.Len(t, actualPushRequest.Streams, 1)
+			assert.Equal(t, tC.expectedLabel, actualPushRequest.Streams[0].Labels)
+
+			// the original data is left untouched
+			assert.Equal(t, len(tC.res), rl.Resource().Attributes().Len())
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithServiceNameAsLabel(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		res           map[string]interface{}
+		attrs         map[string]interface{}
+		expectedLabel string
+	}{
+		{
+			desc: "service name is sent as a label",
+			res: map[string]interface{}{
+				"service.name": "checkout",
+			},
+			expectedLabel: `{exporter="OTLP", service_name="checkout"}`,
+		},
+		{
+			desc: "service name is added to the existing hint",
+			res: map[string]interface{}{
+				"service.name": "checkout",
+				"host.name":    "host-1",
+			},
+			attrs: map[string]interface{}{
+				"loki.resource.labels": "host.name",
+			},
+			expectedLabel: `{exporter="OTLP", host.name="host-1", service_name="checkout"}`,
+		},
+		{
+			desc:          "resources without a service name are left untouched",
+			expectedLabel: `{exporter="OTLP"}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actualPushRequest := &logproto.PushRequest{}
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encPayload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				decPayload, err := snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+
+				err = proto.Unmarshal(decPayload, actualPushRequest)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				ServiceNameAsLabel: true,
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			rl := ld.ResourceLogs().AppendEmpty()
+			rl.Resource().Attributes().FromRaw(tC.res)
+			lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			lr.Attributes().FromRaw(tC.attrs)
+			lr.Body().SetStr("hello")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify
+			require.Len(t, actualPushRequest.Streams, 1)
+			assert.Equal(t, tC.expectedLabel, actualPushRequest.Streams[0].Labels)
+
+			// the original data is left untouched
+			assert.Equal(t, len(tC.res), rl.Resource().Attributes().Len())
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithK8sLabels(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		res           map[string]interface{}
+		attrs         map[string]interface{}
+		expectedLabel string
+	}{
+		{
+			desc: "namespace, pod and container are sent as labels",
+			res: map[string]interface{}{
+				"k8s.namespace.name": "shop",
+				"k8s.pod.name":       "checkout-5d8f7c9b4-x2vkq",
+				"k8s.container.name": "checkout",
+				"k8s.node.name":      "node-1",
+			},
+			expectedLabel: `{exporter="OTLP", k8s_container_name="checkout", k8s_namespace_name="shop", k8s_pod_name="checkout-5d8f7c9b4-x2vkq"}`,
+		},
+		{
+			desc: "missing attributes are skipped",
+			res: map[string]interface{}{
+				"k8s.namespace.name": "shop",
+			},
+			attrs: map[string]interface{}{
+				"loki.resource.labels": "host.name",
+			},
+			expectedLabel: `{exporter="OTLP", k8s_namespace_name="shop"}`,
+		},
+		{
+			desc:          "resources without k8s attributes are left untouched",
+			expectedLabel: `{exporter="OTLP"}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actualPushRequest := &logproto.PushRequest{}
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encPayload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				decPayload, err := snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+
+				err = proto.Unmarshal(decPayload, actualPushRequest)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				K8sLabels: true,
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			rl := ld.ResourceLogs().AppendEmpty()
+			rl.Resource().Attributes().FromRaw(tC.res)
+			lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			lr.Attributes().FromRaw(tC.attrs)
+			lr.Body().SetStr("hello")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify
+			require.Len(t, actualPushRequest.Streams, 1)
+			assert.Equal(t, tC.expectedLabel, actualPushRequest.Streams[0].Labels)
+
+			// the original data is left untouched
+			assert.Equal(t, len(tC.res), rl.Resource().Attributes().Len())
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithLabelConflict(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		labelConflict string
+		attrs         map[string]interface{}
+		expectedLabel string
+	}{
+		{
+			desc:          "attribute wins by default",
+			expectedLabel: `{exporter="OTLP", host.name="from-attribute"}`,
+		},
+		{
+			desc:          "attribute wins",
+			labelConflict: "attribute",
+			expectedLabel: `{exporter="OTLP", host.name="from-attribute"}`,
+		},
+		{
+			desc:          "resource wins",
+			labelConflict: "resource",
+			expectedLabel: `{exporter="OTLP", host.name="from-resource"}`,
+		},
+		{
+			desc:          "the hint of the log record wins over the configuration",
+			labelConflict: "resource",
+			attrs: map[string]interface{}{
+				"loki.label.conflict": "attribute",
+			},
+			expectedLabel: `{exporter="OTLP", host.name="from-attribute"}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actualPushRequest := &logproto.PushRequest{}
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encPayload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				decPayload, err := snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+
+				err = proto.Unmarshal(decPayload, actualPushRequest)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				LabelConflict: tC.labelConflict,
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			rl := ld.ResourceLogs().AppendEmpty()
+			rl.Resource().Attributes().PutStr("host.name", "from-resource")
+			lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			lr.Attributes().FromRaw(tC.attrs)
+			lr.Attributes().PutStr("host.name", "from-attribute")
+			lr.Attributes().PutStr("loki.attribute.labels", "host.name")
+			lr.Attributes().PutStr("loki.resource.labels", "host.name")
+			lr.Body().SetStr("hello")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify
+			require.Len(t, actualPushRequest.Streams, 1)
+			assert.Equal(t, tC.expectedLabel, actualPushRequest.Streams[0].Labels)
+
+			// the original data is left untouched
+			_, found := lr.Attributes().Get("loki.label.conflict")
+			assert.Equal(t, tC.attrs != nil, found)
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithLabelExpressions(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		attrs         map[string]interface{}
+		expectedLabel string
+	}{
+		{
+			desc: "composite label from two attributes",
+			attrs: map[string]interface{}{
+				"namespace": "shop",
+				"app":       "checkout",
+			},
+			expectedLabel: `{exporter="OTLP", workload="shop/checkout"}`,
+		},
+		{
+			desc: "labels are added to the existing hint",
+			attrs: map[string]interface{}{
+				"namespace":             "shop",
+				"app":                   "checkout",
+				"team":                  "payments",
+				"loki.attribute.labels": "app",
+			},
+			expectedLabel: `{app="checkout", exporter="OTLP", team="payments", workload="shop/checkout"}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actualPushRequest := &logproto.PushRequest{}
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encPayload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				decPayload, err := snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+
+				err = proto.Unmarshal(decPayload, actualPushRequest)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				LabelExpressions: map[string]string{
+					"workload": `Concat([attributes["namespace"], attributes["app"]], "/")`,
+					// expressions without value, as the attribute is missing, set no label
+					"team": `attributes["team"]`,
+				},
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			lr.Attributes().FromRaw(tC.attrs)
+			lr.Body().SetStr("hello")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify
+			require.Len(t, actualPushRequest.Streams, 1)
+			assert.Equal(t, tC.expectedLabel, actualPushRequest.Streams[0].Labels)
+
+			// the original data is left untouched
+			assert.Equal(t, len(tC.attrs), lr.Attributes().Len())
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithoutCompression(t *testing.T) {
+	actualPushRequest := &logproto.PushRequest{}
+	var contentEncoding string
+
+	// prepare
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentEncoding = r.Header.Get("Content-Encoding")
+		payload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		// the payload is the marshaled push request, without snappy encoding
+		err = proto.Unmarshal(payload, actualPushRequest)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint:    ts.URL,
+			Compression: compressionNone,
+		},
+	}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	err = exp.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+
+	// test
+	err = exp.ConsumeLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	// verify
+	assert.Empty(t, contentEncoding)
+	require.Len(t, actualPushRequest.Streams, 1)
+	require.Len(t, actualPushRequest.Streams[0].Entries, 1)
+	assert.Equal(t, `{"body":"hello"}`, actualPushRequest.Streams[0].Entries[0].Line)
+
+	// cleanup
+	err = exp.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestPushLogDataWithCompression(t *testing.T) {
+	testCases := []struct {
+		desc                    string
+		compression             configcompression.CompressionType
+		expectedContentEncoding string
+	}{
+		{
+			desc: "snappy by default",
+		},
+		{
+			desc:        "snappy",
+			compression: configcompression.Snappy,
+		},
+		{
+			desc:                    "gzip on top of snappy",
+			compression:             configcompression.Gzip,
+			expectedContentEncoding: "gzip",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actualPushRequest := &logproto.PushRequest{}
+			var contentEncoding string
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contentEncoding = r.Header.Get("Content-Encoding")
+				var body io.Reader = r.Body
+				if contentEncoding == "gzip" {
+					gr, err := gzip.NewReader(r.Body)
+					require.NoError(t, err)
+					body = gr
+				}
+				encPayload, err := io.ReadAll(body)
+				require.NoError(t, err)
+
+				decPayload, err := snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+
+				err = proto.Unmarshal(decPayload, actualPushRequest)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint:    ts.URL,
+					Compression: tC.compression,
+				},
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify
+			assert.Equal(t, tC.expectedContentEncoding, contentEncoding)
+			require.Len(t, actualPushRequest.Streams, 1)
+			require.Len(t, actualPushRequest.Streams[0].Entries, 1)
+			assert.Equal(t, `{"body":"hello"}`, actualPushRequest.Streams[0].Entries[0].Line)
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithGzipCompressionLevel(t *testing.T) {
+	actualPushRequest := &logproto.PushRequest{}
+	var contentEncoding string
+	var gzipExtraFlags byte
+
+	// prepare
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentEncoding = r.Header.Get("Content-Encoding")
+		compressed, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Greater(t, len(compressed), 8)
+		// the XFL byte of the gzip header is set to 2 when the best compression level is used
+		gzipExtraFlags = compressed[8]
+
+		gr, err := gzip.NewReader(bytes.NewReader(compressed))
+		require.NoError(t, err)
+		encPayload, err := io.ReadAll(gr)
+		require.NoError(t, err)
+
+		decPayload, err := snappy.Decode(nil, encPayload)
+		require.NoError(t, err)
+
+		err = proto.Unmarshal(decPayload, actualPushRequest)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint:    ts.URL,
+			Compression: configcompression.Gzip,
+		},
+		CompressionLevel: gzip.BestCompression,
+	}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	err = exp.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+
+	// test
+	err = exp.ConsumeLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, "gzip", contentEncoding)
+	assert.Equal(t, byte(2), gzipExtraFlags)
+	require.Len(t, actualPushRequest.Streams, 1)
+	require.Len(t, actualPushRequest.Streams[0].Entries, 1)
+	assert.Equal(t, `{"body":"hello"}`, actualPushRequest.Streams[0].Entries[0].Line)
+
+	// cleanup
+	err = exp.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestPushLogDataWithStaticTenant(t *testing.T) {
+	tests := []struct {
+		desc     string
+		logs     func() plog.Logs
+		expected map[string]int
+	}{
+		{
+			desc: "all streams go to the static tenant when no hint exists",
+			logs: func() plog.Logs {
+				logs := plog.NewLogs()
+				for _, host := range []string{"host-1", "host-2"} {
+					rl := logs.ResourceLogs().AppendEmpty()
+					rl.Resource().Attributes().PutStr("host.name", host)
+					rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+				}
+				return logs
+			},
+			expected: map[string]int{
+				"acme": 2,
+			},
+		},
+		{
+			desc: "the tenant hint takes precedence over the static tenant",
+			logs: func() plog.Logs {
+				logs := plog.NewLogs()
+				sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+
+				logRecord := sl.LogRecords().AppendEmpty()
+				logRecord.Attributes().PutStr("loki.tenant", "tenant.id")
+				logRecord.Attributes().PutStr("tenant.id", "1")
+
+				logRecord = sl.LogRecords().AppendEmpty()
+				logRecord.Attributes().PutStr("loki.tenant", "tenant.id")
+
+				sl.LogRecords().AppendEmpty().Body().SetStr("hello")
+				return logs
+			},
+			expected: map[string]int{
+				"1":    1,
+				"acme": 2,
+			},
+		},
+	}
+	for _, tC := range tests {
+		t.Run(tC.desc, func(t *testing.T) {
+			entriesPerTenant := map[string]int{}
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encPayload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				decPayload, err := snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+
+				pr := &logproto.PushRequest{}
+				err = proto.Unmarshal(decPayload, pr)
+				require.NoError(t, err)
+
+				for _, stream := range pr.Streams {
+					entriesPerTenant[r.Header.Get("X-Scope-OrgID")] += len(stream.Entries)
+				}
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				StaticTenant: "acme",
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), tC.logs())
+			require.NoError(t, err)
+
+			// verify
+			assert.Equal(t, tC.expected, entriesPerTenant)
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithErrorResponse(t *testing.T) {
+	tests := []struct {
+		desc        string
+		body        string
+		expectedErr string
+	}{
+		{
+			desc:        "the response body is included in the error",
+			body:        "error at least one label pair is required per stream\n",
+			expectedErr: `HTTP 400 "Bad Request": error at least one label pair is required per stream`,
+		},
+		{
+			desc:        "multi-line response bodies are included in the error",
+			body:        "entry for stream has timestamp too old\nentry for stream has timestamp too new\n",
+			expectedErr: "HTTP 400 \"Bad Request\": entry for stream has timestamp too old\nentry for stream has timestamp too new",
+		},
+		{
+			desc:        "long response bodies are truncated",
+			body:        strings.Repeat("a", 2*maxErrMsgLen),
+			expectedErr: `HTTP 400 "Bad Request": ` + strings.Repeat("a", maxErrMsgLen),
+		},
+	}
+	for _, tC := range tests {
+		t.Run(tC.desc, func(t *testing.T) {
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, err := w.Write([]byte(tC.body))
+				assert.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), logs)
+
+			// verify
+			assert.EqualError(t, err, tC.expectedErr)
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithTenantHeader(t *testing.T) {
+	tests := []struct {
+		desc         string
+		tenantHeader string
+		expected     string
+	}{
+		{
+			desc:     "the tenant is sent in the default header",
+			expected: "X-Scope-OrgID",
+		},
+		{
+			desc:         "the tenant is sent in the custom header",
+			tenantHeader: "X-Tenant",
+			expected:     "X-Tenant",
+		},
+	}
+	for _, tC := range tests {
+		t.Run(tC.desc, func(t *testing.T) {
+			var headers http.Header
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				headers = r.Header
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				TenantHeader: tC.tenantHeader,
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			logRecord := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			logRecord.Attributes().PutStr("loki.tenant", "tenant.id")
+			logRecord.Attributes().PutStr("tenant.id", "acme")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), logs)
+			require.NoError(t, err)
+
+			// verify
+			assert.Equal(t, "acme", headers.Get(tC.expected))
+			if tC.expected != "X-Scope-OrgID" {
+				assert.Empty(t, headers.Get("X-Scope-OrgID"))
+			}
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithTenantEndpoints(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string][]string{}
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			received[name] = append(received[name], r.Header.Get("X-Scope-OrgID"))
+		}))
+	}
+
+	// prepare
+	defaultServer := newServer("default")
+	defer defaultServer.Close()
+	acmeServer := newServer("acme")
+	defer acmeServer.Close()
+	globexServer := newServer("globex")
+	defer globexServer.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: defaultServer.URL,
+		},
+		TenantEndpoints: map[string]string{
+			"acme":   acmeServer.URL,
+			"globex": globexServer.URL,
+		},
+	}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	err = exp.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	for _, tenant := range []string{"acme", "globex", "initech", ""} {
+		logRecord := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		logRecord.Body().SetStr("hello")
+		if tenant != "" {
+			logRecord.Attributes().PutStr("loki.tenant", "tenant.id")
+			logRecord.Attributes().PutStr("tenant.id", tenant)
+		}
+	}
+
+	// test
+	err = exp.ConsumeLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, []string{"acme"}, received["acme"])
+	assert.Equal(t, []string{"globex"}, received["globex"])
+	assert.ElementsMatch(t, []string{"initech", ""}, received["default"])
+
+	// cleanup
+	err = exp.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestPushLogDataWithJSONFormat(t *testing.T) {
+	type jsonPush struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][]string        `json:"values"`
+		} `json:"streams"`
+	}
+	actualPushPerTenant := map[string]jsonPush{}
+	var contentTypes []string
+
+	// prepare
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+		payload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		push := jsonPush{}
+		require.NoError(t, json.Unmarshal(payload, &push))
+		actualPushPerTenant[r.Header.Get("X-Scope-OrgID")] = push
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ts.URL,
+		},
+		PushFormat: pushFormatJSON,
+	}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	err = exp.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	for _, tenant := range []string{"1", "2"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("tenant.id", tenant)
+		rl.Resource().Attributes().PutStr("loki.tenant", "tenant.id")
+		logRecord := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		logRecord.SetTimestamp(pcommon.Timestamp(1670000000000000000))
+		logRecord.Body().SetStr("hello from " + tenant)
+		logRecord.Attributes().PutStr("level", "info")
+		logRecord.Attributes().PutStr("loki.attribute.labels", "level")
+	}
+
+	// test
+	err = exp.ConsumeLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, []string{"application/json", "application/json"}, contentTypes)
+	require.Len(t, actualPushPerTenant, 2)
+	for _, tenant := range []string{"1", "2"} {
+		push := actualPushPerTenant[tenant]
+		require.Len(t, push.Streams, 1)
+		assert.Equal(t, map[string]string{"exporter": "OTLP", "level": "info", "tenant.id": tenant}, push.Streams[0].Stream)
+		assert.Equal(t, [][]string{{"1670000000000000000", `{"body":"hello from ` + tenant + `"}`}}, push.Streams[0].Values)
+	}
+
+	// cleanup
+	err = exp.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestPushLogDataWithJSONLineBody(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		body         func(pcommon.Value)
+		expectedBody interface{}
+	}{
+		{
+			desc:         "string body",
+			body:         func(v pcommon.Value) { v.SetStr("hello") },
+			expectedBody: "hello",
+		},
+		{
+			desc: "map body",
+			body: func(v pcommon.Value) {
+				v.SetEmptyMap().PutStr("msg", "hello")
+				v.Map().PutInt("status", 200)
+			},
+			expectedBody: map[string]interface{}{"msg": "hello", "status": float64(200)},
+		},
+		{
+			desc: "slice body",
+			body: func(v pcommon.Value) {
+				v.SetEmptySlice().AppendEmpty().SetStr("hello")
+				v.Slice().AppendEmpty().SetBool(true)
+			},
+			expectedBody: []interface{}{"hello", true},
+		},
+		{
+			desc:         "int body",
+			body:         func(v pcommon.Value) { v.SetInt(42) },
+			expectedBody: float64(42),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actualPushRequest := &logproto.PushRequest{}
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encPayload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				decPayload, err := snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+
+				err = proto.Unmarshal(decPayload, actualPushRequest)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			tC.body(lr.Body())
+			lr.Attributes().PutStr("http.method", "GET")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify
+			require.Len(t, actualPushRequest.Streams, 1)
+			require.Len(t, actualPushRequest.Streams[0].Entries, 1)
+			line := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal([]byte(actualPushRequest.Streams[0].Entries[0].Line), &line))
+			assert.Equal(t, tC.expectedBody, line["body"])
+			assert.Equal(t, map[string]interface{}{"http.method": "GET"}, line["attributes"])
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithOTLPFields(t *testing.T) {
+	tests := []struct {
+		desc          string
+		cfg           OTLPFieldsSettings
+		expectedLabel string
+	}{
+		{
+			desc: "severity number",
+			cfg: OTLPFieldsSettings{
+				SeverityNumber: true,
+			},
+			expectedLabel: `{exporter="OTLP", severity_number="17"}`,
+		},
+		{
+			desc: "all fields",
+			cfg: OTLPFieldsSettings{
+				SeverityNumber:    true,
+				Flags:             true,
+				ObservedTimestamp: true,
+			},
+			expectedLabel: `{exporter="OTLP", flags="1", observed_timestamp="true", severity_number="17"}`,
+		},
+		{
+			desc:          "disabled",
+			expectedLabel: `{exporter="OTLP"}`,
+		},
+	}
+	for _, tC := range tests {
+		t.Run(tC.desc, func(t *testing.T) {
+			actualPushRequest := &logproto.PushRequest{}
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encPayload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				decPayload, err := snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+
+				err = proto.Unmarshal(decPayload, actualPushRequest)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				OTLPFields: tC.cfg,
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			lr.Body().SetStr("hello")
+			lr.Attributes().PutInt("http.status", 500)
+			lr.SetSeverityNumber(plog.SeverityNumberError)
+			lr.SetSeverityText("ERROR")
+			lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+			lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(1, 0)))
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify
+			require.Len(t, actualPushRequest.Streams, 1)
+			assert.Equal(t, tC.expectedLabel, actualPushRequest.Streams[0].Labels)
+			require.Len(t, actualPushRequest.Streams[0].Entries, 1)
+			// the added attributes are promoted to labels and aren't part of the line
+			assert.Equal(t, `{"body":"hello","severity":"ERROR","attributes":{"http.status":500}}`, actualPushRequest.Streams[0].Entries[0].Line)
+
+			// the original log record isn't modified
+			assert.Equal(t, 1, lr.Attributes().Len())
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithMaxLineSize(t *testing.T) {
+	tests := []struct {
+		desc              string
+		policy            string
+		bodies            []string
+		expectedPushes    int
+		expectedLines     []string
+		expectedOversized int64
+	}{
+		{
+			desc:              "drop",
+			policy:            "drop",
+			bodies:            []string{"hello", "0123456789abcdef"},
+			expectedPushes:    1,
+			expectedLines:     []string{`{"body":"hello"}`},
+			expectedOversized: 1,
+		},
+		{
+			desc:              "default policy",
+			bodies:            []string{"hello", "0123456789abcdef"},
+			expectedPushes:    1,
+			expectedLines:     []string{`{"body":"hello"}`},
+			expectedOversized: 1,
+		},
+		{
+			desc:              "truncate",
+			policy:            "truncate",
+			bodies:            []string{"hello", "0123456789abcdef"},
+			expectedPushes:    1,
+			expectedLines:     []string{`{"body":"hello"}`, `{"body":"0123456789a`},
+			expectedOversized: 1,
+		},
+		{
+			desc:              "truncate without splitting characters",
+			policy:            "truncate",
+			bodies:            []string{"0123456789éééé"},
+			expectedPushes:    1,
+			expectedLines:     []string{`{"body":"0123456789`},
+			expectedOversized: 1,
+		},
+		{
+			desc:              "all lines dropped",
+			policy:            "drop",
+			bodies:            []string{"0123456789abcdef", "fedcba9876543210"},
+			expectedOversized: 2,
+		},
+		{
+			desc:           "no oversized line",
+			policy:         "drop",
+			bodies:         []string{"hello"},
+			expectedPushes: 1,
+			expectedLines:  []string{`{"body":"hello"}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			view.Unregister(MetricViews()...)
+			require.NoError(t, view.Register(MetricViews()...))
+			defer view.Unregister(MetricViews()...)
+
+			var pushes int
+			var actualLines []string
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pushes++
+				payload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				push := jsonPushRequest{}
+				require.NoError(t, json.Unmarshal(payload, &push))
+				for _, stream := range push.Streams {
+					for _, value := range stream.Values {
+						actualLines = append(actualLines, value[1].(string))
+					}
+				}
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				PushFormat:        pushFormatJSON,
+				MaxLineSize:       20,
+				MaxLineSizePolicy: tt.policy,
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			logRecords := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+			for _, body := range tt.bodies {
+				logRecord := logRecords.AppendEmpty()
+				logRecord.SetTimestamp(pcommon.Timestamp(1670000000000000000))
+				logRecord.Body().SetStr(body)
+			}
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify
+			assert.Equal(t, tt.expectedPushes, pushes)
+			assert.Equal(t, tt.expectedLines, actualLines)
+
+			rows, err := view.RetrieveData(vOversizedLines.Name)
+			require.NoError(t, err)
+			var oversized int64
+			for _, row := range rows {
+				oversized += int64(row.Data.(*view.SumData).Value)
+			}
+			assert.Equal(t, tt.expectedOversized, oversized)
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithRetryAfter(t *testing.T) {
+	tests := []struct {
+		desc          string
+		status        int
+		retryAfter    string
+		maxInterval   time.Duration
+		expectedDelay time.Duration
+	}{
+		{
+			desc:          "too many requests",
+			status:        http.StatusTooManyRequests,
+			retryAfter:    "1",
+			maxInterval:   time.Minute,
+			expectedDelay: time.Second,
+		},
+		{
+			desc:          "service unavailable",
+			status:        http.StatusServiceUnavailable,
+			retryAfter:    "1",
+			maxInterval:   time.Minute,
+			expectedDelay: time.Second,
+		},
+		{
+			desc:        "capped by the max interval",
+			status:      http.StatusTooManyRequests,
+			retryAfter:  "3600",
+			maxInterval: 100 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var attempts []time.Time
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts = append(attempts, time.Now())
+				if len(attempts) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(tt.status)
+				}
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				RetrySettings: exporterhelper.RetrySettings{
+					Enabled:         true,
+					InitialInterval: 10 * time.Millisecond,
+					MaxInterval:     tt.maxInterval,
+					MaxElapsedTime:  time.Minute,
+				},
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), logs)
+			require.NoError(t, err)
+
+			// verify
+			require.Len(t, attempts, 2)
+			delay := attempts[1].Sub(attempts[0])
+			assert.GreaterOrEqual(t, delay, tt.expectedDelay)
+			assert.Less(t, delay, tt.expectedDelay+30*time.Second)
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithLabelSanitization(t *testing.T) {
+	actualPushRequest := &logproto.PushRequest{}
+
+	// prepare
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encPayload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		decPayload, err := snappy.Decode(nil, encPayload)
+		require.NoError(t, err)
+
+		err = proto.Unmarshal(decPayload, actualPushRequest)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ts.URL,
+		},
+		LabelSanitization: true,
+	}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	err = exp.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "guarana")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutInt("http.status_code", 200)
+	lr.Attributes().PutStr("loki.resource.labels", "host.name")
+	lr.Attributes().PutStr("loki.attribute.labels", "http.status_code")
+	lr.Body().SetStr("hello")
+
+	// test
+	err = exp.ConsumeLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	// verify
+	require.Len(t, actualPushRequest.Streams, 1)
+	assert.Equal(t, `{exporter="OTLP", host_name="guarana", http_status_code="200"}`, actualPushRequest.Streams[0].Labels)
+
+	// cleanup
+	err = exp.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestPushLogDataWithTenantMetrics(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	require.NoError(t, view.Register(MetricViews()...))
+	defer view.Unregister(MetricViews()...)
+
+	receivedBytes := map[string]int64{}
+
+	// prepare
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		receivedBytes[r.Header.Get("X-Scope-OrgID")] += int64(len(payload))
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ts.URL,
+		},
+	}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	err = exp.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	for tenant, levels := range map[string][]string{"1": {"info", "info", "error"}, "2": {"info"}} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("tenant.id", tenant)
+		rl.Resource().Attributes().PutStr("loki.tenant", "tenant.id")
+		logRecords := rl.ScopeLogs().AppendEmpty().LogRecords()
+		for _, level := range levels {
+			logRecord := logRecords.AppendEmpty()
+			logRecord.Body().SetStr("hello")
+			logRecord.Attributes().PutStr("level", level)
+			logRecord.Attributes().PutStr("loki.attribute.labels", "level")
+		}
+	}
+
+	// test
+	err = exp.ConsumeLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	// verify
+	sumPerTenant := func(v *view.View) map[string]int64 {
+		rows, err := view.RetrieveData(v.Name)
+		require.NoError(t, err)
+		sums := map[string]int64{}
+		for _, row := range rows {
+			for _, tag := range row.Tags {
+				if tag.Key == tagTenant {
+					sums[tag.Value] += int64(row.Data.(*view.SumData).Value)
+				}
+			}
+		}
+		return sums
+	}
+	assert.Equal(t, map[string]int64{"1": 2, "2": 1}, sumPerTenant(vSentStreams))
+	assert.Equal(t, map[string]int64{"1": 3, "2": 1}, sumPerTenant(vSentEntries))
+	assert.Equal(t, receivedBytes, sumPerTenant(vSentBytes))
+
+	// cleanup
+	err = exp.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestPushLogDataWithOTLPFormat(t *testing.T) {
+	actualPushRequest := &logproto.PushRequest{}
+
+	// prepare
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encPayload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		decPayload, err := snappy.Decode(nil, encPayload)
+		require.NoError(t, err)
+
+		err = proto.Unmarshal(decPayload, actualPushRequest)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ts.URL,
+		},
+		LineFormat: "otlp",
+	}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	err = exp.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "guarana")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("io.opentelemetry.example")
+	sl.Scope().SetVersion("1.0.0")
+	lr := sl.LogRecords().AppendEmpty()
+	lr.Attributes().PutInt("http.status_code", 200)
+	lr.Attributes().PutStr("loki.resource.labels", "host.name")
+	lr.Body().SetStr("hello")
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 1670000000000000000)))
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 1670000000000000001)))
+	lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+
+	// test
+	err = exp.ConsumeLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	// verify
+	require.Len(t, actualPushRequest.Streams, 1)
+	assert.Equal(t, `{exporter="OTLP", host.name="guarana"}`, actualPushRequest.Streams[0].Labels)
+	require.Len(t, actualPushRequest.Streams[0].Entries, 1)
+
+	line, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs([]byte(actualPushRequest.Streams[0].Entries[0].Line))
+	require.NoError(t, err)
+	require.Equal(t, 1, line.LogRecordCount())
+	actualScope := line.ResourceLogs().At(0).ScopeLogs().At(0)
+	assert.Equal(t, "io.opentelemetry.example", actualScope.Scope().Name())
+	assert.Equal(t, "1.0.0", actualScope.Scope().Version())
+	actual := actualScope.LogRecords().At(0)
+	assert.Equal(t, "hello", actual.Body().Str())
+	assert.Equal(t, map[string]interface{}{"http.status_code": int64(200)}, actual.Attributes().AsRaw())
+	assert.Equal(t, lr.ObservedTimestamp(), actual.ObservedTimestamp())
+	assert.True(t, actual.Flags().IsSampled())
+
+	// cleanup
+	err = exp.Shutdown(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestPushLogDataWithStructuredMetadata(t *testing.T) {
+	tests := []struct {
+		desc               string
+		structuredMetadata bool
+		expectedLine       string
+		expectedMetadata   [][]map[string]string
+	}{
+		{
+			desc:               "structured metadata enabled",
+			structuredMetadata: true,
+			expectedLine:       `{"body":"hello"}`,
+			expectedMetadata:   [][]map[string]string{{{"trace.id": "1"}, {"trace.id": "2"}}},
+		},
+		{
+			desc:             "structured metadata disabled",
+			expectedLine:     `{"body":"hello","attributes":{"trace.id":"1"}}`,
+			expectedMetadata: [][]map[string]string{{{}, {}}},
+		},
+	}
+	for _, tC := range tests {
+		t.Run(tC.desc, func(t *testing.T) {
+			var payload []byte
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encPayload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				payload, err = snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				StructuredMetadata: tC.structuredMetadata,
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			logs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+			for _, traceID := range []string{"1", "2"} {
+				lr := logs.AppendEmpty()
+				lr.Attributes().PutStr("trace.id", traceID)
+				lr.Attributes().PutStr("loki.metadata.labels", "trace.id")
+				lr.Body().SetStr("hello")
+			}
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify: the entries share a stream whatever their structured metadata
+			actualPushRequest := &logproto.PushRequest{}
+			require.NoError(t, proto.Unmarshal(payload, actualPushRequest))
+			require.Len(t, actualPushRequest.Streams, 1)
+			require.Len(t, actualPushRequest.Streams[0].Entries, 2)
+			assert.Equal(t, `{exporter="OTLP"}`, actualPushRequest.Streams[0].Labels)
+			assert.Equal(t, tC.expectedLine, actualPushRequest.Streams[0].Entries[0].Line)
+			assert.Equal(t, tC.expectedMetadata, decodeStructuredMetadata(t, payload))
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushLogDataWithPreserveTypes(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		preserveTypes bool
+		attrs         map[string]interface{}
+		expectedLine  string
+	}{
+		{
+			desc:         "types aren't preserved by default",
+			expectedLine: `{"body":"hello","attributes":{"duration":2,"http.status":200,"success":true}}`,
+		},
+		{
+			desc:          "types are preserved",
+			preserveTypes: true,
+			expectedLine:  `{"body":"hello","attributes":{"duration":2.0,"http.status":200,"success":true}}`,
+		},
+		{
+			desc:          "the hint of the log record wins over the configuration",
+			preserveTypes: true,
+			attrs: map[string]interface{}{
+				"loki.preserve.types": false,
+			},
+			expectedLine: `{"body":"hello","attributes":{"duration":2,"http.status":200,"success":true}}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actualPushRequest := &logproto.PushRequest{}
+
+			// prepare
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encPayload, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				decPayload, err := snappy.Decode(nil, encPayload)
+				require.NoError(t, err)
+
+				err = proto.Unmarshal(decPayload, actualPushRequest)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: ts.URL,
+				},
+				PreserveTypes: tC.preserveTypes,
+			}
+
+			f := NewFactory()
+			exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			ld := plog.NewLogs()
+			lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			lr.Attributes().FromRaw(tC.attrs)
+			lr.Attributes().PutInt("http.status", 200)
+			lr.Attributes().PutDouble("duration", 2)
+			lr.Attributes().PutBool("success", true)
+			lr.Body().SetStr("hello")
+
+			// test
+			err = exp.ConsumeLogs(context.Background(), ld)
+			require.NoError(t, err)
+
+			// verify
+			require.Len(t, actualPushRequest.Streams, 1)
+			require.Len(t, actualPushRequest.Streams[0].Entries, 1)
+			assert.Equal(t, tC.expectedLine, actualPushRequest.Streams[0].Entries[0].Line)
+
+			// cleanup
+			err = exp.Shutdown(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
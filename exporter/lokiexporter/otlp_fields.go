@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	labelSeverityNumber    = "severity_number"
+	labelFlags             = "flags"
+	labelObservedTimestamp = "observed_timestamp"
+)
+
+// addOTLPFields sets the configured OTLP fields of every log record of ld as its attributes, along with the hint
+// that these attributes should be promoted to labels.
+func addOTLPFields(ld plog.Logs, cfg OTLPFieldsSettings) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			logs := sls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				attrs := lr.Attributes()
+				if cfg.SeverityNumber {
+					attrs.PutInt(labelSeverityNumber, int64(lr.SeverityNumber()))
+					addLabelHint(attrs, hintAttributes, labelSeverityNumber)
+				}
+				if cfg.Flags {
+					attrs.PutInt(labelFlags, int64(lr.Flags()))
+					addLabelHint(attrs, hintAttributes, labelFlags)
+				}
+				if cfg.ObservedTimestamp {
+					present := lr.ObservedTimestamp() != pcommon.Timestamp(0)
+					attrs.PutStr(labelObservedTimestamp, strconv.FormatBool(present))
+					addLabelHint(attrs, hintAttributes, labelObservedTimestamp)
+				}
+			}
+		}
+	}
+}
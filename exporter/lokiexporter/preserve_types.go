@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// hintPreserveTypes is the attribute hinting that the doubles of the JSON line keep a decimal point, so that they
+// can be told apart from the ints, as read by the Loki translator.
+const hintPreserveTypes = "loki.preserve.types"
+
+// setPreserveTypes hints on every log record of ld without a preserve types hint that the types of its attributes
+// and body are preserved in the line.
+func setPreserveTypes(ld plog.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			logs := sls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				attrs := logs.At(k).Attributes()
+				if _, ok := attrs.Get(hintPreserveTypes); !ok {
+					attrs.PutBool(hintPreserveTypes, true)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	hintAttributes = "loki.attribute.labels"
+	hintResources  = "loki.resource.labels"
+
+	provenanceSeparator = ","
+)
+
+// addProvenance sets the provenance attribute on every resource of ld, and hints on every log record that this
+// attribute should be promoted to a label.
+func addProvenance(ld plog.Logs, cfg ProvenanceSettings) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		attrs := rls.At(i).Resource().Attributes()
+		value := cfg.Value
+		if existing, ok := attrs.Get(cfg.Label); ok && cfg.Append && existing.AsString() != "" {
+			value = existing.AsString() + provenanceSeparator + value
+		}
+		attrs.PutStr(cfg.Label, value)
+
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			logs := sls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				addLabelHint(logs.At(k).Attributes(), hintResources, cfg.Label)
+			}
+		}
+	}
+}
+
+// addLabelHint adds the given attribute name to the given labels hint, preserving the
+// attribute names already present in it.
+func addLabelHint(attrs pcommon.Map, hintName string, name string) {
+	hint, ok := attrs.Get(hintName)
+	if !ok {
+		attrs.PutStr(hintName, name)
+		return
+	}
+
+	switch hint.Type() {
+	case pcommon.ValueTypeSlice:
+		hint.Slice().AppendEmpty().SetStr(name)
+	default:
+		attrs.PutStr(hintName, hint.AsString()+","+name)
+	}
+}
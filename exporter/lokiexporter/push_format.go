@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	pushFormatProtobuf = "protobuf"
+	pushFormatJSON     = "json"
+)
+
+// jsonPushRequest is the JSON body of a push request, as documented by the Loki HTTP API.
+type jsonPushRequest struct {
+	Streams []jsonStream `json:"streams"`
+}
+
+type jsonStream struct {
+	Stream map[string]string `json:"stream"`
+	// Values holds the entries of the stream as pairs of a timestamp, in nanoseconds, and a line,
+	// followed by the structured metadata of the entry, if any.
+	Values [][]interface{} `json:"values"`
+}
+
+// encodeJSON marshals the push request into the JSON body accepted by Loki. The structured metadata,
+// if not nil, holds the structured metadata of each entry, at the same indexes as the stream and the entry.
+func encodeJSON(pushReq *logproto.PushRequest, metadata [][]model.LabelSet) ([]byte, error) {
+	req := jsonPushRequest{Streams: make([]jsonStream, 0, len(pushReq.Streams))}
+	for i, s := range pushReq.Streams {
+		labels, err := parseLabels(s.Labels)
+		if err != nil {
+			return nil, err
+		}
+		values := make([][]interface{}, 0, len(s.Entries))
+		for j, e := range s.Entries {
+			value := []interface{}{strconv.FormatInt(e.Timestamp.UnixNano(), 10), e.Line}
+			if metadata != nil && len(metadata[i][j]) > 0 {
+				value = append(value, metadata[i][j])
+			}
+			values = append(values, value)
+		}
+		req.Streams = append(req.Streams, jsonStream{Stream: labels, Values: values})
+	}
+	return json.Marshal(req)
+}
+
+// parseLabels parses the labels of a stream, formatted as a label set, e.g. `{exporter="OTLP", job="app"}`.
+func parseLabels(s string) (map[string]string, error) {
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, fmt.Errorf("invalid stream labels %q", s)
+	}
+	rest := s[1 : len(s)-1]
+
+	labels := map[string]string{}
+	for rest != "" {
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return nil, fmt.Errorf("invalid stream labels %q", s)
+		}
+		name := rest[:eq]
+		quoted, err := strconv.QuotedPrefix(rest[eq+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid stream labels %q: %w", s, err)
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stream labels %q: %w", s, err)
+		}
+		labels[name] = value
+		rest = strings.TrimPrefix(rest[eq+1+len(quoted):], ", ")
+	}
+	return labels, nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabels(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		labels   string
+		expected map[string]string
+	}{
+		{
+			desc:     "no labels",
+			labels:   "{}",
+			expected: map[string]string{},
+		},
+		{
+			desc:   "several labels",
+			labels: `{exporter="OTLP", host.name="host-1", level="error"}`,
+			expected: map[string]string{
+				"exporter":  "OTLP",
+				"host.name": "host-1",
+				"level":     "error",
+			},
+		},
+		{
+			desc:   "escaped values",
+			labels: `{msg="say \"hi\", then leave", path="C:\\logs\n"}`,
+			expected: map[string]string{
+				"msg":  `say "hi", then leave`,
+				"path": "C:\\logs\n",
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			labels, err := parseLabels(tC.labels)
+			require.NoError(t, err)
+			assert.Equal(t, tC.expected, labels)
+		})
+	}
+}
+
+func TestParseLabelsInvalid(t *testing.T) {
+	for _, labels := range []string{``, `exporter="OTLP"`, `{exporter}`, `{="OTLP"}`, `{exporter="OTLP}`, `{exporter=OTLP}`} {
+		_, err := parseLabels(labels)
+		assert.Error(t, err, labels)
+	}
+}
+
+func TestEncodeJSONWithStructuredMetadata(t *testing.T) {
+	pushReq := &logproto.PushRequest{
+		Streams: []logproto.Stream{
+			{Labels: `{exporter="OTLP"}`, Entries: []logproto.Entry{{Timestamp: time.Unix(0, 1), Line: "first"}}},
+			{Labels: `{exporter="OTLP"}`, Entries: []logproto.Entry{{Timestamp: time.Unix(0, 2), Line: "second"}}},
+		},
+	}
+
+	buf, err := encodeJSON(pushReq, [][]model.LabelSet{{{"trace_id": "1"}}, {{}}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"streams":[
+		{"stream":{"exporter":"OTLP"},"values":[["1","first",{"trace_id":"1"}]]},
+		{"stream":{"exporter":"OTLP"},"values":[["2","second"]]}
+	]}`, string(buf))
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const headerRetryAfter = "Retry-After"
+
+// retryAfter returns how long to wait before retrying a push request rejected with the given response, as
+// requested by its Retry-After header, either in seconds or as an HTTP date. The delay is capped by maxDelay,
+// if positive. Zero is returned when the header is absent or invalid, leaving the delay to the retry settings.
+func retryAfter(resp *http.Response, maxDelay time.Duration) time.Duration {
+	val := strings.TrimSpace(resp.Header.Get(headerRetryAfter))
+	if val == "" {
+		return 0
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(val); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(val); err == nil {
+		delay = date.Sub(timeNow())
+	}
+
+	if delay < 0 {
+		return 0
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
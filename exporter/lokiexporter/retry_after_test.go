@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	testCases := []struct {
+		desc     string
+		header   string
+		maxDelay time.Duration
+		expected time.Duration
+	}{
+		{
+			desc: "absent",
+		},
+		{
+			desc:     "seconds",
+			header:   "5",
+			expected: 5 * time.Second,
+		},
+		{
+			desc:     "http date",
+			header:   "Tue, 01 Nov 2022 12:00:30 GMT",
+			expected: 30 * time.Second,
+		},
+		{
+			desc:   "http date in the past",
+			header: "Tue, 01 Nov 2022 11:59:00 GMT",
+		},
+		{
+			desc:   "negative seconds",
+			header: "-5",
+		},
+		{
+			desc:   "invalid",
+			header: "soon",
+		},
+		{
+			desc:     "capped by the max delay",
+			header:   "3600",
+			maxDelay: 30 * time.Second,
+			expected: 30 * time.Second,
+		},
+		{
+			desc:     "http date capped by the max delay",
+			header:   "Tue, 01 Nov 2022 13:00:00 GMT",
+			maxDelay: time.Minute,
+			expected: time.Minute,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tC.header != "" {
+				resp.Header.Set("Retry-After", tC.header)
+			}
+			assert.Equal(t, tC.expected, retryAfter(resp, tC.maxDelay))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+// labelServiceName is the name of the label for the service name, as "service.name" isn't a valid label name.
+const labelServiceName = "service_name"
+
+// addServiceNameLabel copies the service name of every resource of ld to its "service_name" attribute, which its
+// log records hint should be promoted to a label.
+func addServiceNameLabel(ld plog.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		attrs := rls.At(i).Resource().Attributes()
+		serviceName, ok := attrs.Get(conventions.AttributeServiceName)
+		if !ok {
+			continue
+		}
+		attrs.PutStr(labelServiceName, serviceName.AsString())
+
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			logs := sls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				addLabelHint(logs.At(k).Attributes(), hintResources, labelServiceName)
+			}
+		}
+	}
+}
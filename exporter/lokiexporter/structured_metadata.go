@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"sort"
+
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/prometheus/common/model"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// hintMetadata is the attribute listing the log attributes to send as structured metadata, as read by the Loki translator.
+const hintMetadata = "loki.metadata.labels"
+
+// Field numbers of the Loki 3 push API, whose entries have structured metadata unlike the vendored logproto types.
+const (
+	fieldPushRequestStreams      protowire.Number = 1
+	fieldStreamLabels            protowire.Number = 1
+	fieldStreamEntries           protowire.Number = 2
+	fieldEntryStructuredMetadata protowire.Number = 3
+	fieldLabelPairName           protowire.Number = 1
+	fieldLabelPairValue          protowire.Number = 2
+)
+
+// removeMetadataHint removes the structured metadata hint from every log record of ld, so that the attributes
+// it lists stay in the lines.
+func removeMetadataHint(ld plog.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			logs := sls.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				logs.At(k).Attributes().Remove(hintMetadata)
+			}
+		}
+	}
+}
+
+// encodeWithStructuredMetadata marshals the push request with its structured metadata, which is snappy-encoded
+// unless compression is "none".
+func encodeWithStructuredMetadata(pushReq *logproto.PushRequest, metadata [][]model.LabelSet, compression configcompression.CompressionType) ([]byte, error) {
+	buf, err := marshalWithStructuredMetadata(pushReq, metadata)
+	if err != nil {
+		return nil, err
+	}
+	if compression == compressionNone {
+		return buf, nil
+	}
+	return snappy.Encode(nil, buf), nil
+}
+
+// marshalWithStructuredMetadata marshals the push request into protobuf, adding the structured metadata of each
+// entry, at the same indexes as the stream and the entry. As the fields of a message can be encoded in any order,
+// the structured metadata is appended to the entries marshaled by logproto.
+func marshalWithStructuredMetadata(pushReq *logproto.PushRequest, metadata [][]model.LabelSet) ([]byte, error) {
+	var buf []byte
+	for i, stream := range pushReq.Streams {
+		var streamBuf []byte
+		streamBuf = protowire.AppendTag(streamBuf, fieldStreamLabels, protowire.BytesType)
+		streamBuf = protowire.AppendString(streamBuf, stream.Labels)
+
+		for j, entry := range stream.Entries {
+			entryBuf, err := entry.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			for _, pair := range marshalLabelPairs(metadata[i][j]) {
+				entryBuf = protowire.AppendTag(entryBuf, fieldEntryStructuredMetadata, protowire.BytesType)
+				entryBuf = protowire.AppendBytes(entryBuf, pair)
+			}
+			streamBuf = protowire.AppendTag(streamBuf, fieldStreamEntries, protowire.BytesType)
+			streamBuf = protowire.AppendBytes(streamBuf, entryBuf)
+		}
+
+		buf = protowire.AppendTag(buf, fieldPushRequestStreams, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, streamBuf)
+	}
+	return buf, nil
+}
+
+// marshalLabelPairs marshals the label set into label pairs, sorted by name.
+func marshalLabelPairs(labels model.LabelSet) [][]byte {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	pairs := make([][]byte, 0, len(names))
+	for _, name := range names {
+		var pair []byte
+		pair = protowire.AppendTag(pair, fieldLabelPairName, protowire.BytesType)
+		pair = protowire.AppendString(pair, name)
+		pair = protowire.AppendTag(pair, fieldLabelPairValue, protowire.BytesType)
+		pair = protowire.AppendString(pair, string(labels[model.LabelName(name)]))
+		pairs = append(pairs, pair)
+	}
+	return pairs
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/loki/pkg/logproto"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+func TestMarshalWithStructuredMetadata(t *testing.T) {
+	pushReq := &logproto.PushRequest{
+		Streams: []logproto.Stream{
+			{
+				Labels: `{exporter="OTLP"}`,
+				Entries: []logproto.Entry{
+					{Timestamp: time.Unix(0, 1).UTC(), Line: "first"},
+					{Timestamp: time.Unix(0, 2).UTC(), Line: "second"},
+				},
+			},
+			{
+				Labels:  `{exporter="OTLP"}`,
+				Entries: []logproto.Entry{{Timestamp: time.Unix(0, 3).UTC(), Line: "third"}},
+			},
+		},
+	}
+	metadata := [][]model.LabelSet{{{"trace_id": "1", "span_id": "2"}, {"trace_id": "3"}}, {nil}}
+
+	buf, err := marshalWithStructuredMetadata(pushReq, metadata)
+	require.NoError(t, err)
+
+	// the push request can still be decoded by clients ignoring the structured metadata
+	decoded := &logproto.PushRequest{}
+	require.NoError(t, decoded.Unmarshal(buf))
+	assert.Equal(t, pushReq, decoded)
+
+	assert.Equal(t, [][]map[string]string{
+		{{"span_id": "2", "trace_id": "1"}, {"trace_id": "3"}},
+		{{}},
+	}, decodeStructuredMetadata(t, buf))
+}
+
+func TestRemoveMetadataHint(t *testing.T) {
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutStr("trace.id", "1")
+	lr.Attributes().PutStr(hintMetadata, "trace.id")
+
+	removeMetadataHint(ld)
+	assert.Equal(t, map[string]interface{}{"trace.id": "1"}, lr.Attributes().AsRaw())
+}
+
+func TestStreamHelpersKeepStructuredMetadata(t *testing.T) {
+	request := loki.PushRequest{
+		PushRequest: &logproto.PushRequest{
+			Streams: []logproto.Stream{
+				{Labels: `{host.name="a"}`, Entries: []logproto.Entry{{Line: "toolong"}, {Line: "0"}}},
+				{Labels: `{host_name="a"}`, Entries: []logproto.Entry{{Line: "1"}}},
+				{Labels: `{host.name="a"}`, Entries: []logproto.Entry{{Line: "2"}}},
+				{Labels: `{host.name="b"}`, Entries: []logproto.Entry{{Line: "3"}}},
+			},
+		},
+		Report:             &loki.PushReport{NumSubmitted: 5},
+		StructuredMetadata: [][]model.LabelSet{{{"trace_id": "9"}, {"trace_id": "0"}}, {{"trace_id": "1"}}, {{"trace_id": "2"}}, {{"trace_id": "3"}}},
+	}
+
+	assert.Equal(t, 1, applyLineSizePolicy(&request, 5, lineSizePolicyDrop))
+	require.NoError(t, rewriteLabels(&request, func(labels map[string]string) model.LabelSet {
+		out := model.LabelSet{}
+		for name, value := range labels {
+			out[model.LabelName(sanitizeLabelName(name))] = model.LabelValue(value)
+		}
+		return out
+	}))
+
+	assert.Equal(t, []logproto.Stream{
+		{Labels: `{host_name="a"}`, Entries: []logproto.Entry{{Line: "0"}, {Line: "1"}, {Line: "2"}}},
+		{Labels: `{host_name="b"}`, Entries: []logproto.Entry{{Line: "3"}}},
+	}, request.Streams)
+	assert.Equal(t, [][]model.LabelSet{{{"trace_id": "0"}, {"trace_id": "1"}, {"trace_id": "2"}}, {{"trace_id": "3"}}}, request.StructuredMetadata)
+}
+
+// decodeStructuredMetadata returns the structured metadata of the entries of each stream of the push request.
+func decodeStructuredMetadata(t *testing.T, buf []byte) [][]map[string]string {
+	var out [][]map[string]string
+	forEachField(t, buf, fieldPushRequestStreams, func(stream []byte) {
+		var entries []map[string]string
+		forEachField(t, stream, fieldStreamEntries, func(entry []byte) {
+			metadata := map[string]string{}
+			forEachField(t, entry, fieldEntryStructuredMetadata, func(pair []byte) {
+				var name string
+				forEachField(t, pair, fieldLabelPairName, func(b []byte) { name = string(b) })
+				forEachField(t, pair, fieldLabelPairValue, func(b []byte) { metadata[name] = string(b) })
+			})
+			entries = append(entries, metadata)
+		})
+		out = append(out, entries)
+	})
+	return out
+}
+
+// forEachField calls fn with the value of every length-delimited field of the message with the given number.
+func forEachField(t *testing.T, msg []byte, field protowire.Number, fn func([]byte)) {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		require.GreaterOrEqual(t, n, 0)
+		msg = msg[n:]
+		n = protowire.ConsumeFieldValue(num, typ, msg)
+		require.GreaterOrEqual(t, n, 0)
+		if num == field && typ == protowire.BytesType {
+			value, _ := protowire.ConsumeBytes(msg)
+			fn(value)
+		}
+		msg = msg[n:]
+	}
+}
@@ -15,25 +15,111 @@
 package opencensusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/opencensusexporter"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 )
 
+// minConnectTimeout is the minimum time given to an attempt to connect, as set by gRPC by default.
+const minConnectTimeout = 20 * time.Second
+
 // Config defines configuration for OpenCensus exporter.
 type Config struct {
-	config.ExporterSettings       `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
-	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
-	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
-	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+	config.ExporterSettings        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	configgrpc.GRPCClientSettings  `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
 
 	// The number of workers that send the gRPC requests.
 	NumWorkers int `mapstructure:"num_workers"`
+
+	// NumStreams is the number of concurrent metrics RPCs opened on the connection, which the sends are
+	// assigned to in turn. It replaces NumWorkers for metrics, as a stream sends one request at a time.
+	// Zero means NumWorkers streams.
+	NumStreams int `mapstructure:"num_streams"`
+
+	// The maximum number of requests being sent at any moment, across workers. Requests exceeding
+	// it fail with a retryable error instead of waiting for a worker. Zero means no limit.
+	MaxInFlight int `mapstructure:"max_in_flight"`
+
+	// FallbackEndpoints are tried in order when the current endpoint fails to create an RPC or to send
+	// the data. The exporter keeps using the endpoint it failed over to until it fails too.
+	FallbackEndpoints []string `mapstructure:"fallback_endpoints"`
+
+	// UserAgent is sent in the User-Agent header of the gRPC requests, so that the backend can identify
+	// the traffic of the collector. Defaults to the name and version of the collector.
+	UserAgent string `mapstructure:"user_agent"`
+
+	// ReconnectionDelay is the delay before reconnecting to an endpoint after the connection was broken,
+	// growing exponentially on each failed attempt up to MaxReconnectionDelay. Defaults to the gRPC
+	// backoff, starting at 1s.
+	ReconnectionDelay time.Duration `mapstructure:"reconnection_delay"`
+
+	// MaxReconnectionDelay is the maximum delay between two attempts to reconnect. Defaults to the
+	// gRPC backoff, which is capped at 120s.
+	MaxReconnectionDelay time.Duration `mapstructure:"max_reconnection_delay"`
 }
 
 var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	if keepalive := cfg.GRPCClientSettings.Keepalive; keepalive != nil {
+		if keepalive.Time < 0 {
+			return errors.New("\"keepalive.time\" must not be negative")
+		}
+		if keepalive.Timeout < 0 {
+			return errors.New("\"keepalive.timeout\" must not be negative")
+		}
+	}
+	if cfg.NumStreams < 0 {
+		return errors.New("\"num_streams\" must not be negative")
+	}
+	if cfg.ReconnectionDelay < 0 {
+		return errors.New("\"reconnection_delay\" must not be negative")
+	}
+	if cfg.MaxReconnectionDelay < 0 {
+		return errors.New("\"max_reconnection_delay\" must not be negative")
+	}
+	if cfg.MaxReconnectionDelay > 0 && cfg.MaxReconnectionDelay < cfg.ReconnectionDelay {
+		return errors.New("\"max_reconnection_delay\" must not be less than \"reconnection_delay\"")
+	}
 	return nil
 }
+
+// numMetricsStreams returns the number of metrics RPCs the exporter keeps open.
+func (cfg *Config) numMetricsStreams() int {
+	if cfg.NumStreams > 0 {
+		return cfg.NumStreams
+	}
+	return cfg.NumWorkers
+}
+
+// connectParams returns the backoff of the attempts to reconnect, if either of the reconnection delays is set.
+func (cfg *Config) connectParams() (grpc.ConnectParams, bool) {
+	if cfg.ReconnectionDelay <= 0 && cfg.MaxReconnectionDelay <= 0 {
+		return grpc.ConnectParams{}, false
+	}
+	bo := backoff.DefaultConfig
+	if cfg.ReconnectionDelay > 0 {
+		bo.BaseDelay = cfg.ReconnectionDelay
+	}
+	if cfg.MaxReconnectionDelay > 0 {
+		bo.MaxDelay = cfg.MaxReconnectionDelay
+	}
+	// the default delay of the unset option gives way to the configured one
+	if bo.MaxDelay < bo.BaseDelay {
+		if cfg.MaxReconnectionDelay > 0 {
+			bo.BaseDelay = bo.MaxDelay
+		} else {
+			bo.MaxDelay = bo.BaseDelay
+		}
+	}
+	return grpc.ConnectParams{Backoff: bo, MinConnectTimeout: minConnectTimeout}, true
+}
@@ -46,6 +46,9 @@ func TestLoadConfig(t *testing.T) {
 			id: config.NewComponentIDWithName(typeStr, "2"),
 			expected: &Config{
 				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+				TimeoutSettings: exporterhelper.TimeoutSettings{
+					Timeout: 10 * time.Second,
+				},
 				RetrySettings: exporterhelper.RetrySettings{
 					Enabled:         true,
 					InitialInterval: 10 * time.Second,
@@ -79,7 +82,16 @@ func TestLoadConfig(t *testing.T) {
 					WriteBufferSize: 512 * 1024,
 					BalancerName:    "round_robin",
 				},
-				NumWorkers: 123,
+				NumWorkers:  123,
+				NumStreams:  4,
+				MaxInFlight: 10,
+				FallbackEndpoints: []string{
+					"1.2.3.5:1234",
+					"1.2.3.6:1234",
+				},
+				UserAgent:            "acme-collector/1.2.3",
+				ReconnectionDelay:    2 * time.Second,
+				MaxReconnectionDelay: 30 * time.Second,
 			},
 		},
 	}
@@ -98,3 +110,103 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateKeepalive(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Keepalive = &configgrpc.KeepaliveClientConfig{
+		Time:                30 * time.Second,
+		Timeout:             10 * time.Second,
+		PermitWithoutStream: true,
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Keepalive.Time = -time.Second
+	assert.EqualError(t, cfg.Validate(), "\"keepalive.time\" must not be negative")
+
+	cfg.Keepalive.Time = 30 * time.Second
+	cfg.Keepalive.Timeout = -time.Second
+	assert.EqualError(t, cfg.Validate(), "\"keepalive.timeout\" must not be negative")
+}
+
+func TestValidateNumStreams(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, cfg.NumWorkers, cfg.numMetricsStreams())
+
+	cfg.NumStreams = 8
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, 8, cfg.numMetricsStreams())
+
+	cfg.NumStreams = -1
+	assert.EqualError(t, cfg.Validate(), "\"num_streams\" must not be negative")
+}
+
+func TestValidateReconnectionDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		delay    time.Duration
+		maxDelay time.Duration
+		err      string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:     "both set",
+			delay:    time.Second,
+			maxDelay: 10 * time.Second,
+		},
+		{
+			name:  "delay only",
+			delay: time.Second,
+		},
+		{
+			name:  "negative delay",
+			delay: -time.Second,
+			err:   "\"reconnection_delay\" must not be negative",
+		},
+		{
+			name:     "negative max delay",
+			maxDelay: -time.Second,
+			err:      "\"max_reconnection_delay\" must not be negative",
+		},
+		{
+			name:     "max delay less than delay",
+			delay:    10 * time.Second,
+			maxDelay: time.Second,
+			err:      "\"max_reconnection_delay\" must not be less than \"reconnection_delay\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.ReconnectionDelay = tt.delay
+			cfg.MaxReconnectionDelay = tt.maxDelay
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestConnectParams(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	_, ok := cfg.connectParams()
+	assert.False(t, ok)
+
+	cfg.ReconnectionDelay = 500 * time.Millisecond
+	params, ok := cfg.connectParams()
+	assert.True(t, ok)
+	assert.Equal(t, 500*time.Millisecond, params.Backoff.BaseDelay)
+	assert.Equal(t, 120*time.Second, params.Backoff.MaxDelay)
+
+	cfg.ReconnectionDelay = 0
+	cfg.MaxReconnectionDelay = 500 * time.Millisecond
+	params, ok = cfg.connectParams()
+	assert.True(t, ok)
+	// the default base delay is capped by the max delay
+	assert.Equal(t, 500*time.Millisecond, params.Backoff.BaseDelay)
+	assert.Equal(t, 500*time.Millisecond, params.Backoff.MaxDelay)
+}
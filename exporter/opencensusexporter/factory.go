@@ -16,7 +16,9 @@ package opencensusexporter // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"sync"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -31,8 +33,15 @@ const (
 	stability = component.StabilityLevelBeta
 )
 
+var once sync.Once
+
 // NewFactory creates a factory for OTLP exporter.
 func NewFactory() component.ExporterFactory {
+	once.Do(func() {
+		// TODO: as with other -contrib factories registering metrics, this is causing the error being ignored
+		_ = view.Register(MetricViews()...)
+	})
+
 	return component.NewExporterFactory(
 		typeStr,
 		createDefaultConfig,
@@ -48,44 +57,63 @@ func createDefaultConfig() config.Exporter {
 			// We almost read 0 bytes, so no need to tune ReadBufferSize.
 			WriteBufferSize: 512 * 1024,
 		},
-		NumWorkers: 2,
+		TimeoutSettings: exporterhelper.NewDefaultTimeoutSettings(),
+		NumWorkers:      2,
 	}
 }
 
 func createTracesExporter(ctx context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.TracesExporter, error) {
 	oCfg := cfg.(*Config)
-	oce, err := newTracesExporter(ctx, oCfg, set.TelemetrySettings)
+	oce, err := newTracesExporter(ctx, oCfg, set)
 	if err != nil {
 		return nil, err
 	}
 
-	return exporterhelper.NewTracesExporter(
+	if oCfg.QueueSettings.Enabled {
+		oce.queue = newQueueTracker(oCfg.ID().String(), "traces")
+	}
+
+	exp, err := exporterhelper.NewTracesExporter(
 		ctx,
 		set,
 		cfg,
 		oce.pushTraces,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown))
+	if err != nil || oce.queue == nil {
+		return exp, err
+	}
+	return &queueTracesExporter{TracesExporter: exp, queue: oce.queue}, nil
 }
 
 func createMetricsExporter(ctx context.Context, set component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
 	oCfg := cfg.(*Config)
-	oce, err := newMetricsExporter(ctx, oCfg, set.TelemetrySettings)
+	oce, err := newMetricsExporter(ctx, oCfg, set)
 	if err != nil {
 		return nil, err
 	}
 
-	return exporterhelper.NewMetricsExporter(
+	if oCfg.QueueSettings.Enabled {
+		oce.queue = newQueueTracker(oCfg.ID().String(), "metrics")
+	}
+
+	exp, err := exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
 		cfg,
 		oce.pushMetrics,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
 		exporterhelper.WithRetry(oCfg.RetrySettings),
 		exporterhelper.WithQueue(oCfg.QueueSettings),
 		exporterhelper.WithStart(oce.start),
 		exporterhelper.WithShutdown(oce.shutdown))
+	if err != nil || oce.queue == nil {
+		return exp, err
+	}
+	return &queueMetricsExporter{MetricsExporter: exp, queue: oce.queue}, nil
 }
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/opencensusexporter"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagExporterName, _ = tag.NewKey("exporter_name")
+	tagSignal, _       = tag.NewKey("signal")
+
+	mQueueBatches = stats.Int64("opencensus_exporter_queue_batches", "Number of batches in the sending queue, waiting to be sent", stats.UnitDimensionless)
+	vQueueBatches = &view.View{
+		Name:        mQueueBatches.Name(),
+		Measure:     mQueueBatches,
+		Description: mQueueBatches.Description(),
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{tagExporterName, tagSignal},
+	}
+
+	mEnqueuedBatches = stats.Int64("opencensus_exporter_enqueued_batches", "Number of batches added to the sending queue", stats.UnitDimensionless)
+	vEnqueuedBatches = &view.View{
+		Name:        mEnqueuedBatches.Name(),
+		Measure:     mEnqueuedBatches,
+		Description: mEnqueuedBatches.Description(),
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagExporterName, tagSignal},
+	}
+
+	mDequeuedBatches = stats.Int64("opencensus_exporter_dequeued_batches", "Number of batches taken from the sending queue to be sent", stats.UnitDimensionless)
+	vDequeuedBatches = &view.View{
+		Name:        mDequeuedBatches.Name(),
+		Measure:     mDequeuedBatches,
+		Description: mDequeuedBatches.Description(),
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tagExporterName, tagSignal},
+	}
+)
+
+// MetricViews return the metrics views according to given telemetry level.
+func MetricViews() []*view.View {
+	return []*view.View{vQueueBatches, vEnqueuedBatches, vDequeuedBatches}
+}
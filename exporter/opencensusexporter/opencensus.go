@@ -18,6 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
 
 	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
 	agentmetricspb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/metrics/v1"
@@ -26,6 +29,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
@@ -37,6 +42,8 @@ import (
 type tracesClientWithCancel struct {
 	cancel context.CancelFunc
 	tsec   agenttracepb.TraceService_ExportClient
+	// endpoint is the index of the endpoint the RPC was created for.
+	endpoint int
 }
 
 // See https://godoc.org/google.golang.org/grpc#ClientConn.NewStream
@@ -44,24 +51,43 @@ type tracesClientWithCancel struct {
 type metricsClientWithCancel struct {
 	cancel context.CancelFunc
 	msec   agentmetricspb.MetricsService_ExportClient
+	// endpoint is the index of the endpoint the RPC was created for.
+	endpoint int
 }
 
-type ocExporter struct {
-	cfg *Config
-	// gRPC clients and connection.
+// ocEndpoint holds the gRPC connection and clients of one of the configured endpoints.
+type ocEndpoint struct {
+	address          string
+	conn             *grpc.ClientConn
 	traceSvcClient   agenttracepb.TraceServiceClient
 	metricsSvcClient agentmetricspb.MetricsServiceClient
-	// In any of the channels we keep always NumWorkers object (sometimes nil),
+}
+
+type ocExporter struct {
+	cfg *Config
+	// endpoints holds the primary endpoint followed by the fallback endpoints.
+	endpoints []*ocEndpoint
+	// active is the index of the endpoint new RPCs are created for, accessed atomically.
+	active int32
+	// In the traces channel we keep always NumWorkers object (sometimes nil),
 	// to make sure we don't open more than NumWorkers RPCs at any moment.
-	tracesClients  chan *tracesClientWithCancel
+	tracesClients chan *tracesClientWithCancel
+	// In the metrics channel we keep always numMetricsStreams objects (sometimes nil). As the
+	// sends take the clients from the front and put them back at the end, the streams are used
+	// in turn.
 	metricsClients chan *metricsClientWithCancel
-	grpcClientConn *grpc.ClientConn
-	metadata       metadata.MD
+	// metadata holds the configured headers, attached to the outgoing context of every stream.
+	metadata  metadata.MD
+	userAgent string
+	// inFlight holds a token for each request being sent, nil if the number of requests isn't limited.
+	inFlight chan struct{}
+	// queue tracks the batches in the sending queue, nil if the queue isn't enabled.
+	queue *queueTracker
 
 	settings component.TelemetrySettings
 }
 
-func newOcExporter(_ context.Context, cfg *Config, settings component.TelemetrySettings) (*ocExporter, error) {
+func newOcExporter(_ context.Context, cfg *Config, set component.ExporterCreateSettings) (*ocExporter, error) {
 	if cfg.Endpoint == "" {
 		return nil, errors.New("OpenCensus exporter cfg requires an Endpoint")
 	}
@@ -70,24 +96,93 @@ func newOcExporter(_ context.Context, cfg *Config, settings component.TelemetryS
 		return nil, errors.New("OpenCensus exporter cfg requires at least one worker")
 	}
 
+	if cfg.MaxInFlight < 0 {
+		return nil, errors.New("OpenCensus exporter cfg requires a non-negative max_in_flight")
+	}
+
+	for _, endpoint := range cfg.FallbackEndpoints {
+		if endpoint == "" {
+			return nil, errors.New("OpenCensus exporter cfg requires non-empty fallback_endpoints")
+		}
+	}
+
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = fmt.Sprintf("%s/%s (%s/%s)", set.BuildInfo.Description, set.BuildInfo.Version, runtime.GOOS, runtime.GOARCH)
+	}
+
 	oce := &ocExporter{
-		cfg:      cfg,
-		metadata: metadata.New(cfg.GRPCClientSettings.Headers),
-		settings: settings,
+		cfg:       cfg,
+		metadata:  metadata.New(cfg.GRPCClientSettings.Headers),
+		userAgent: userAgent,
+		settings:  set.TelemetrySettings,
+	}
+	if cfg.MaxInFlight > 0 {
+		oce.inFlight = make(chan struct{}, cfg.MaxInFlight)
 	}
 	return oce, nil
 }
 
-// start creates the gRPC client Connection
+// errTooManyInFlight is returned when max_in_flight requests are already being sent.
+// It isn't permanent, so the request is retried.
+var errTooManyInFlight = errors.New("too many requests in flight")
+
+// acquireInFlight reserves a slot for a request, failing immediately if none is available.
+// The returned function releases the slot.
+func (oce *ocExporter) acquireInFlight() (func(), error) {
+	if oce.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case oce.inFlight <- struct{}{}:
+		return func() { <-oce.inFlight }, nil
+	default:
+		return nil, errTooManyInFlight
+	}
+}
+
+// activeEndpoint returns the index of the endpoint new RPCs are created for.
+func (oce *ocExporter) activeEndpoint() int {
+	return int(atomic.LoadInt32(&oce.active))
+}
+
+// failover makes the endpoint following the failed one active. Nothing changes if another worker
+// already failed over from it, so that concurrent failures don't skip endpoints.
+func (oce *ocExporter) failover(failed int) {
+	next := (failed + 1) % len(oce.endpoints)
+	if next == failed || !atomic.CompareAndSwapInt32(&oce.active, int32(failed), int32(next)) {
+		return
+	}
+	oce.settings.Logger.Warn("Failing over to the next OpenCensus endpoint",
+		zap.String("failed", oce.endpoints[failed].address),
+		zap.String("endpoint", oce.endpoints[next].address))
+}
+
+// start creates the gRPC client Connections
 func (oce *ocExporter) start(ctx context.Context, host component.Host) error {
-	clientConn, err := oce.cfg.GRPCClientSettings.ToClientConn(ctx, host, oce.settings)
-	if err != nil {
-		return err
+	dialOpts := []grpc.DialOption{grpc.WithUserAgent(oce.userAgent)}
+	if params, ok := oce.cfg.connectParams(); ok {
+		// gRPC reconnects broken connections in the background, so that the RPCs can be recreated
+		// on the next sends once the endpoint is back.
+		dialOpts = append(dialOpts, grpc.WithConnectParams(params))
+	}
+	addresses := append([]string{oce.cfg.Endpoint}, oce.cfg.FallbackEndpoints...)
+	for _, address := range addresses {
+		clientSettings := oce.cfg.GRPCClientSettings
+		clientSettings.Endpoint = address
+		clientConn, err := clientSettings.ToClientConn(ctx, host, oce.settings, dialOpts...)
+		if err != nil {
+			return err
+		}
+		oce.endpoints = append(oce.endpoints, &ocEndpoint{
+			address:          address,
+			conn:             clientConn,
+			traceSvcClient:   agenttracepb.NewTraceServiceClient(clientConn),
+			metricsSvcClient: agentmetricspb.NewMetricsServiceClient(clientConn),
+		})
 	}
-	oce.grpcClientConn = clientConn
 
 	if oce.tracesClients != nil {
-		oce.traceSvcClient = agenttracepb.NewTraceServiceClient(oce.grpcClientConn)
 		// Try to create rpc clients now.
 		for i := 0; i < oce.cfg.NumWorkers; i++ {
 			// Populate the channel with NumWorkers nil RPCs to keep the number of workers
@@ -97,10 +192,9 @@ func (oce *ocExporter) start(ctx context.Context, host component.Host) error {
 	}
 
 	if oce.metricsClients != nil {
-		oce.metricsSvcClient = agentmetricspb.NewMetricsServiceClient(oce.grpcClientConn)
 		// Try to create rpc clients now.
-		for i := 0; i < oce.cfg.NumWorkers; i++ {
-			// Populate the channel with NumWorkers nil RPCs to keep the number of workers
+		for i := 0; i < oce.cfg.numMetricsStreams(); i++ {
+			// Populate the channel with numMetricsStreams nil RPCs to keep the number of streams
 			// constant in the channel.
 			oce.metricsClients <- nil
 		}
@@ -108,28 +202,36 @@ func (oce *ocExporter) start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
-func (oce *ocExporter) shutdown(context.Context) error {
+func (oce *ocExporter) shutdown(ctx context.Context) error {
 	if oce.tracesClients != nil {
-		// First remove all the clients from the channel.
+		// First remove all the clients from the channel, waiting for the sends in progress.
 		for i := 0; i < oce.cfg.NumWorkers; i++ {
-			<-oce.tracesClients
+			if client := <-oce.tracesClients; client != nil {
+				closeRPC(ctx, client.cancel, client.tsec, &agenttracepb.ExportTraceServiceResponse{})
+			}
 		}
 		// Now close the channel
 		close(oce.tracesClients)
 	}
 	if oce.metricsClients != nil {
-		// First remove all the clients from the channel.
-		for i := 0; i < oce.cfg.NumWorkers; i++ {
-			<-oce.metricsClients
+		// First remove all the clients from the channel, waiting for the sends in progress.
+		for i := 0; i < oce.cfg.numMetricsStreams(); i++ {
+			if client := <-oce.metricsClients; client != nil {
+				closeRPC(ctx, client.cancel, client.msec, &agentmetricspb.ExportMetricsServiceResponse{})
+			}
 		}
 		// Now close the channel
 		close(oce.metricsClients)
 	}
-	return oce.grpcClientConn.Close()
+	var errs error
+	for _, endpoint := range oce.endpoints {
+		errs = multierr.Append(errs, endpoint.conn.Close())
+	}
+	return errs
 }
 
-func newTracesExporter(ctx context.Context, cfg *Config, settings component.TelemetrySettings) (*ocExporter, error) {
-	oce, err := newOcExporter(ctx, cfg, settings)
+func newTracesExporter(ctx context.Context, cfg *Config, set component.ExporterCreateSettings) (*ocExporter, error) {
+	oce, err := newOcExporter(ctx, cfg, set)
 	if err != nil {
 		return nil, err
 	}
@@ -137,37 +239,83 @@ func newTracesExporter(ctx context.Context, cfg *Config, settings component.Tele
 	return oce, nil
 }
 
-func newMetricsExporter(ctx context.Context, cfg *Config, settings component.TelemetrySettings) (*ocExporter, error) {
-	oce, err := newOcExporter(ctx, cfg, settings)
+func newMetricsExporter(ctx context.Context, cfg *Config, set component.ExporterCreateSettings) (*ocExporter, error) {
+	oce, err := newOcExporter(ctx, cfg, set)
 	if err != nil {
 		return nil, err
 	}
-	oce.metricsClients = make(chan *metricsClientWithCancel, oce.cfg.NumWorkers)
+	oce.metricsClients = make(chan *metricsClientWithCancel, oce.cfg.numMetricsStreams())
 	return oce, nil
 }
 
-func (oce *ocExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
-	// Get first available trace Client.
-	tClient, ok := <-oce.tracesClients
-	if !ok {
-		err := errors.New("failed to push traces, OpenCensus exporter was already stopped")
+func (oce *ocExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
+	oce.queue.pushed(ctx)
+
+	release, err := oce.acquireInFlight()
+	if err != nil {
 		return err
 	}
+	defer release()
+
+	// Get first available trace Client.
+	var tClient *tracesClientWithCancel
+	select {
+	case client, ok := <-oce.tracesClients:
+		if !ok {
+			err := errors.New("failed to push traces, OpenCensus exporter was already stopped")
+			return err
+		}
+		tClient = client
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 
 	// In any of the metricsClients channel we keep always NumWorkers object (sometimes nil),
 	// to make sure we don't open more than NumWorkers RPCs at any moment.
 	// Here check if the client is nil and create a new one if that is the case. A nil
 	// object means that an error happened: could not connect, service went down, etc.
-	if tClient == nil {
-		var err error
-		tClient, err = oce.createTraceServiceRPC()
-		if err != nil {
-			// Cannot create an RPC, put back nil to keep the number of workers constant.
-			oce.tracesClients <- nil
-			return err
+	// Each endpoint is tried at most once, starting with the active one, failing over to
+	// the next one when the RPC cannot be created or the data cannot be sent.
+	var errs error
+	for attempt := 0; attempt < len(oce.endpoints); attempt++ {
+		if tClient != nil && tClient.endpoint != oce.activeEndpoint() {
+			// The exporter failed over since the RPC was created, recreate it for the active endpoint.
+			tClient.cancel()
+			tClient = nil
+		}
+		if tClient == nil {
+			endpoint := oce.activeEndpoint()
+			tClient, err = oce.createTraceServiceRPC(endpoint)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				oce.failover(endpoint)
+				continue
+			}
+		}
+
+		if err = oce.sendTraces(ctx, tClient, td); err == nil {
+			oce.tracesClients <- tClient
+			return nil
 		}
+		errs = multierr.Append(errs, err)
+		failed := tClient.endpoint
+		tClient = nil
+		if ctx.Err() != nil {
+			// The deadline of the call was exceeded, which isn't a failure of the endpoint.
+			break
+		}
+		oce.failover(failed)
 	}
+	// Put back nil to keep the number of workers constant.
+	oce.tracesClients <- nil
+	return errs
+}
 
+// sendTraces sends td over the RPC of tClient. On failure, the RPC is canceled and must not be reused.
+func (oce *ocExporter) sendTraces(ctx context.Context, tClient *tracesClientWithCancel, td ptrace.Traces) error {
+	// The RPC outlives this call, so it is canceled if the deadline of ctx is exceeded
+	// while sending, in which case it will be recreated on the next call.
+	stopWatching := cancelWhenDone(ctx, tClient.cancel)
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
 		node, resource, spans := opencensus.ResourceSpansToOC(rss.At(i))
@@ -184,39 +332,88 @@ func (oce *ocExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
 			Node:     node,
 		}
 		if err := tClient.tsec.Send(req); err != nil {
-			// Error received, cancel the context used to create the RPC to free all resources,
-			// put back nil to keep the number of workers constant.
+			// Error received, cancel the context used to create the RPC to free all resources.
+			if stopWatching() {
+				err = ctx.Err()
+			}
 			tClient.cancel()
-			oce.tracesClients <- nil
 			return err
 		}
 	}
-	oce.tracesClients <- tClient
+	if stopWatching() {
+		return ctx.Err()
+	}
 	return nil
 }
 
-func (oce *ocExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
-	// Get first available mClient.
-	mClient, ok := <-oce.metricsClients
-	if !ok {
-		err := errors.New("failed to push metrics, OpenCensus exporter was already stopped")
+func (oce *ocExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	oce.queue.pushed(ctx)
+
+	release, err := oce.acquireInFlight()
+	if err != nil {
 		return err
 	}
+	defer release()
 
-	// In any of the metricsClients channel we keep always NumWorkers object (sometimes nil),
-	// to make sure we don't open more than NumWorkers RPCs at any moment.
+	// Get first available mClient.
+	var mClient *metricsClientWithCancel
+	select {
+	case client, ok := <-oce.metricsClients:
+		if !ok {
+			err := errors.New("failed to push metrics, OpenCensus exporter was already stopped")
+			return err
+		}
+		mClient = client
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// In the metricsClients channel we keep always numMetricsStreams objects (sometimes nil),
+	// to make sure we don't open more than numMetricsStreams RPCs at any moment.
 	// Here check if the client is nil and create a new one if that is the case. A nil
 	// object means that an error happened: could not connect, service went down, etc.
-	if mClient == nil {
-		var err error
-		mClient, err = oce.createMetricsServiceRPC()
-		if err != nil {
-			// Cannot create an RPC, put back nil to keep the number of workers constant.
-			oce.metricsClients <- nil
-			return err
+	// Each endpoint is tried at most once, starting with the active one, failing over to
+	// the next one when the RPC cannot be created or the data cannot be sent.
+	var errs error
+	for attempt := 0; attempt < len(oce.endpoints); attempt++ {
+		if mClient != nil && mClient.endpoint != oce.activeEndpoint() {
+			// The exporter failed over since the RPC was created, recreate it for the active endpoint.
+			mClient.cancel()
+			mClient = nil
+		}
+		if mClient == nil {
+			endpoint := oce.activeEndpoint()
+			mClient, err = oce.createMetricsServiceRPC(endpoint)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				oce.failover(endpoint)
+				continue
+			}
+		}
+
+		if err = oce.sendMetrics(ctx, mClient, md); err == nil {
+			oce.metricsClients <- mClient
+			return nil
+		}
+		errs = multierr.Append(errs, err)
+		failed := mClient.endpoint
+		mClient = nil
+		if ctx.Err() != nil {
+			// The deadline of the call was exceeded, which isn't a failure of the endpoint.
+			break
 		}
+		oce.failover(failed)
 	}
+	// Put back nil to keep the number of streams constant.
+	oce.metricsClients <- nil
+	return errs
+}
 
+// sendMetrics sends md over the RPC of mClient. On failure, the RPC is canceled and must not be reused.
+func (oce *ocExporter) sendMetrics(ctx context.Context, mClient *metricsClientWithCancel, md pmetric.Metrics) error {
+	// The RPC outlives this call, so it is canceled if the deadline of ctx is exceeded
+	// while sending, in which case it will be recreated on the next call.
+	stopWatching := cancelWhenDone(ctx, mClient.cancel)
 	rms := md.ResourceMetrics()
 	for i := 0; i < rms.Len(); i++ {
 		ocReq := agentmetricspb.ExportMetricsServiceRequest{}
@@ -230,43 +427,94 @@ func (oce *ocExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error
 			ocReq.Resource = &resourcepb.Resource{}
 		}
 		if err := mClient.msec.Send(&ocReq); err != nil {
-			// Error received, cancel the context used to create the RPC to free all resources,
-			// put back nil to keep the number of workers constant.
+			// Error received, cancel the context used to create the RPC to free all resources.
+			if stopWatching() {
+				err = ctx.Err()
+			}
 			mClient.cancel()
-			oce.metricsClients <- nil
 			return err
 		}
 	}
-	oce.metricsClients <- mClient
+	if stopWatching() {
+		return ctx.Err()
+	}
 	return nil
 }
 
-func (oce *ocExporter) createTraceServiceRPC() (*tracesClientWithCancel, error) {
+// cancelWhenDone calls cancel if ctx is done before the returned function is called.
+// The returned function reports whether cancel was called.
+func cancelWhenDone(ctx context.Context, cancel context.CancelFunc) func() bool {
+	if ctx.Done() == nil {
+		return func() bool { return false }
+	}
+
+	stop := make(chan struct{})
+	canceled := make(chan bool, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+			canceled <- true
+		case <-stop:
+			canceled <- false
+		}
+	}()
+
+	var once sync.Once
+	var result bool
+	return func() bool {
+		once.Do(func() {
+			close(stop)
+			result = <-canceled
+		})
+		return result
+	}
+}
+
+// closeRPC half-closes the RPC and waits for the backend to end it, so that the data already sent
+// is processed, until ctx is done. The RPC is then canceled to free all resources.
+func closeRPC(ctx context.Context, cancel context.CancelFunc, stream grpc.ClientStream, resp interface{}) {
+	defer cancel()
+	if err := stream.CloseSend(); err != nil {
+		return
+	}
+	stopWatching := cancelWhenDone(ctx, cancel)
+	defer stopWatching()
+	for {
+		if err := stream.RecvMsg(resp); err != nil {
+			return
+		}
+	}
+}
+
+func (oce *ocExporter) createTraceServiceRPC(endpoint int) (*tracesClientWithCancel, error) {
 	// Initiate the trace service by sending over node identifier info.
 	ctx, cancel := context.WithCancel(context.Background())
-	if len(oce.cfg.Headers) > 0 {
-		ctx = metadata.NewOutgoingContext(ctx, metadata.New(oce.cfg.Headers))
+	// The headers are sent when the stream is opened, so they apply to all the requests sent on it.
+	if oce.metadata.Len() > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, oce.metadata)
 	}
 	// Cannot use grpc.WaitForReady(cfg.WaitForReady) because will block forever.
-	traceClient, err := oce.traceSvcClient.Export(ctx)
+	traceClient, err := oce.endpoints[endpoint].traceSvcClient.Export(ctx)
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("TraceServiceClient: %w", err)
 	}
-	return &tracesClientWithCancel{cancel: cancel, tsec: traceClient}, nil
+	return &tracesClientWithCancel{cancel: cancel, tsec: traceClient, endpoint: endpoint}, nil
 }
 
-func (oce *ocExporter) createMetricsServiceRPC() (*metricsClientWithCancel, error) {
+func (oce *ocExporter) createMetricsServiceRPC(endpoint int) (*metricsClientWithCancel, error) {
 	// Initiate the trace service by sending over node identifier info.
 	ctx, cancel := context.WithCancel(context.Background())
-	if len(oce.cfg.Headers) > 0 {
-		ctx = metadata.NewOutgoingContext(ctx, metadata.New(oce.cfg.Headers))
+	// The headers are sent when the stream is opened, so they apply to all the requests sent on it.
+	if oce.metadata.Len() > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, oce.metadata)
 	}
 	// Cannot use grpc.WaitForReady(cfg.WaitForReady) because will block forever.
-	metricsClient, err := oce.metricsSvcClient.Export(ctx)
+	metricsClient, err := oce.endpoints[endpoint].metricsSvcClient.Export(ctx)
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("MetricsServiceClient: %w", err)
 	}
-	return &metricsClientWithCancel{cancel: cancel, msec: metricsClient}, nil
+	return &metricsClientWithCancel{cancel: cancel, msec: metricsClient, endpoint: endpoint}, nil
 }
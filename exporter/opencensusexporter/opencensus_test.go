@@ -16,16 +16,27 @@ package opencensusexporter
 
 import (
 	"context"
+	"net"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	agentmetricspb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/metrics/v1"
+	agenttracepb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/trace/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
@@ -111,6 +122,50 @@ func TestSendTraces_NoBackend(t *testing.T) {
 	}
 }
 
+func TestSendTraces_Reconnect(t *testing.T) {
+	endpoint := testutil.GetAvailableLocalAddress(t)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: endpoint,
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.NumWorkers = 1
+	cfg.ReconnectionDelay = 10 * time.Millisecond
+	cfg.MaxReconnectionDelay = 50 * time.Millisecond
+	exp, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	})
+
+	td := testdata.GenerateTracesOneSpan()
+	assert.Error(t, exp.ConsumeTraces(context.Background(), td))
+
+	// the backend comes up after the first send failed
+	sink := new(consumertest.TracesSink)
+	rFactory := opencensusreceiver.NewFactory()
+	rCfg := rFactory.CreateDefaultConfig().(*opencensusreceiver.Config)
+	rCfg.GRPCServerSettings.NetAddr.Endpoint = endpoint
+	recv, err := rFactory.CreateTracesReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), rCfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, recv.Shutdown(context.Background()))
+	})
+
+	assert.Eventually(t, func() bool {
+		return exp.ConsumeTraces(context.Background(), td) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return len(sink.AllTraces()) > 0
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
 func TestSendTraces_AfterStop(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
@@ -227,3 +282,463 @@ func TestSendMetrics_AfterStop(t *testing.T) {
 	md := testdata.GenerateMetricsOneMetric()
 	assert.Error(t, exp.ConsumeMetrics(context.Background(), md))
 }
+
+func TestSendTraces_ContextDeadline(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: "localhost:56569",
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.NumWorkers = 1
+	oce, err := newTracesExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, oce.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, oce.shutdown(context.Background()))
+	})
+
+	// Hold the only worker so the push has to wait for one.
+	client := <-oce.tracesClients
+	defer func() { oce.tracesClients <- client }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = oce.pushTraces(ctx, testdata.GenerateTracesOneSpan())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), cfg.Timeout)
+}
+
+func TestSendMetrics_ContextDeadline(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: "localhost:56569",
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.NumWorkers = 1
+	oce, err := newMetricsExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, oce.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, oce.shutdown(context.Background()))
+	})
+
+	// Hold the only worker so the push has to wait for one.
+	client := <-oce.metricsClients
+	defer func() { oce.metricsClients <- client }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = oce.pushMetrics(ctx, testdata.GenerateMetricsOneMetric())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), cfg.Timeout)
+}
+
+func TestSendTraces_MaxInFlight(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: "localhost:56569",
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.MaxInFlight = 2
+	oce, err := newTracesExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, oce.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, oce.shutdown(context.Background()))
+	})
+
+	// Saturate the in-flight capacity.
+	var releases []func()
+	for i := 0; i < cfg.MaxInFlight; i++ {
+		release, acquireErr := oce.acquireInFlight()
+		require.NoError(t, acquireErr)
+		releases = append(releases, release)
+	}
+
+	err = oce.pushTraces(context.Background(), testdata.GenerateTracesOneSpan())
+	assert.ErrorIs(t, err, errTooManyInFlight)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	// Once a slot is released, the request is sent again.
+	releases[0]()
+	err = oce.pushTraces(context.Background(), testdata.GenerateTracesOneSpan())
+	assert.NotErrorIs(t, err, errTooManyInFlight)
+	releases[1]()
+}
+
+func TestSendMetrics_MaxInFlight(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: "localhost:56569",
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.MaxInFlight = 1
+	oce, err := newMetricsExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, oce.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, oce.shutdown(context.Background()))
+	})
+
+	// Saturate the in-flight capacity.
+	release, err := oce.acquireInFlight()
+	require.NoError(t, err)
+
+	err = oce.pushMetrics(context.Background(), testdata.GenerateMetricsOneMetric())
+	assert.ErrorIs(t, err, errTooManyInFlight)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	release()
+	err = oce.pushMetrics(context.Background(), testdata.GenerateMetricsOneMetric())
+	assert.NotErrorIs(t, err, errTooManyInFlight)
+}
+
+// streamsMetricsServer counts the Export RPCs it receives, and the ones ended by the client.
+type streamsMetricsServer struct {
+	agentmetricspb.UnimplementedMetricsServiceServer
+	opened   int32
+	closed   int32
+	requests int32
+}
+
+func (s *streamsMetricsServer) Export(stream agentmetricspb.MetricsService_ExportServer) error {
+	atomic.AddInt32(&s.opened, 1)
+	for {
+		if _, err := stream.Recv(); err != nil {
+			atomic.AddInt32(&s.closed, 1)
+			return nil
+		}
+		atomic.AddInt32(&s.requests, 1)
+	}
+}
+
+func TestSendMetrics_NumStreams(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := &streamsMetricsServer{}
+	server := grpc.NewServer()
+	agentmetricspb.RegisterMetricsServiceServer(server, srv)
+	go func() {
+		_ = server.Serve(ln)
+	}()
+	t.Cleanup(server.Stop)
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.NumWorkers = 1
+	cfg.NumStreams = 3
+	oce, err := newMetricsExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, oce.start(context.Background(), componenttest.NewNopHost()))
+
+	// The sends are assigned to the streams in turn, so each stream is opened once and then reused.
+	for i := 0; i < 6; i++ {
+		require.NoError(t, oce.pushMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+	}
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&srv.requests) == 6
+	}, 10*time.Second, 5*time.Millisecond)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&srv.opened))
+
+	// Shutdown ends every stream after the data sent on it was received.
+	require.NoError(t, oce.shutdown(context.Background()))
+	assert.EqualValues(t, 3, atomic.LoadInt32(&srv.closed))
+}
+
+func TestNewOcExporter_InvalidMaxInFlight(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:56569"
+	cfg.MaxInFlight = -1
+	_, err := newOcExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	assert.Error(t, err)
+}
+
+func TestSendTraces_FallbackEndpoint(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	rFactory := opencensusreceiver.NewFactory()
+	rCfg := rFactory.CreateDefaultConfig().(*opencensusreceiver.Config)
+	fallback := testutil.GetAvailableLocalAddress(t)
+	rCfg.GRPCServerSettings.NetAddr.Endpoint = fallback
+	set := componenttest.NewNopReceiverCreateSettings()
+	recv, err := rFactory.CreateTracesReceiver(context.Background(), set, rCfg, sink)
+	assert.NoError(t, err)
+	assert.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, recv.Shutdown(context.Background()))
+	})
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		// Nothing listens on the primary endpoint.
+		Endpoint: testutil.GetAvailableLocalAddress(t),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.FallbackEndpoints = []string{fallback}
+	cfg.NumWorkers = 1
+	oce, err := newTracesExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, oce.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, oce.shutdown(context.Background()))
+	})
+
+	td := testdata.GenerateTracesOneSpan()
+	assert.NoError(t, oce.pushTraces(context.Background(), td))
+	assert.Equal(t, 1, oce.activeEndpoint())
+
+	// The exporter keeps using the fallback endpoint.
+	assert.NoError(t, oce.pushTraces(context.Background(), td))
+	assert.Equal(t, 1, oce.activeEndpoint())
+	assert.Eventually(t, func() bool {
+		return len(sink.AllTraces()) == 2
+	}, 10*time.Second, 5*time.Millisecond)
+	for _, traces := range sink.AllTraces() {
+		assert.Equal(t, td, traces)
+	}
+}
+
+func TestSendMetrics_FallbackEndpoint(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	rFactory := opencensusreceiver.NewFactory()
+	rCfg := rFactory.CreateDefaultConfig().(*opencensusreceiver.Config)
+	fallback := testutil.GetAvailableLocalAddress(t)
+	rCfg.GRPCServerSettings.NetAddr.Endpoint = fallback
+	set := componenttest.NewNopReceiverCreateSettings()
+	recv, err := rFactory.CreateMetricsReceiver(context.Background(), set, rCfg, sink)
+	assert.NoError(t, err)
+	assert.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, recv.Shutdown(context.Background()))
+	})
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		// Nothing listens on the primary endpoint.
+		Endpoint: testutil.GetAvailableLocalAddress(t),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.FallbackEndpoints = []string{fallback}
+	cfg.NumWorkers = 1
+	oce, err := newMetricsExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, oce.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, oce.shutdown(context.Background()))
+	})
+
+	md := testdata.GenerateMetricsOneMetric()
+	assert.NoError(t, oce.pushMetrics(context.Background(), md))
+	assert.Equal(t, 1, oce.activeEndpoint())
+	assert.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == 1
+	}, 10*time.Second, 5*time.Millisecond)
+	assert.Equal(t, md, sink.AllMetrics()[0])
+}
+
+func TestSendTraces_AllEndpointsFail(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: testutil.GetAvailableLocalAddress(t),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.FallbackEndpoints = []string{testutil.GetAvailableLocalAddress(t)}
+	cfg.NumWorkers = 1
+	oce, err := newTracesExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, oce.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, oce.shutdown(context.Background()))
+	})
+
+	err = oce.pushTraces(context.Background(), testdata.GenerateTracesOneSpan())
+	require.Error(t, err)
+	// Both endpoints were tried, so the exporter is back on the primary one.
+	assert.Len(t, multierr.Errors(err), 2)
+	assert.Equal(t, 0, oce.activeEndpoint())
+}
+
+func TestNewOcExporter_InvalidFallbackEndpoints(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:56569"
+	cfg.FallbackEndpoints = []string{""}
+	_, err := newOcExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	assert.Error(t, err)
+}
+
+// userAgentTraceServer records the User-Agent header of the Export RPCs it receives.
+type userAgentTraceServer struct {
+	agenttracepb.UnimplementedTraceServiceServer
+	userAgents chan string
+}
+
+func (s *userAgentTraceServer) Export(stream agenttracepb.TraceService_ExportServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.userAgents <- strings.Join(md.Get("user-agent"), ",")
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return nil
+		}
+	}
+}
+
+func TestSendTraces_UserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		expected  string
+	}{
+		{
+			name:      "configured",
+			userAgent: "acme-collector/1.2.3",
+			expected:  "acme-collector/1.2.3",
+		},
+		{
+			name:     "default",
+			expected: "Custom Collector/v1.0.0 (" + runtime.GOOS + "/" + runtime.GOARCH + ")",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "localhost:0")
+			require.NoError(t, err)
+			srv := &userAgentTraceServer{userAgents: make(chan string, 1)}
+			server := grpc.NewServer()
+			agenttracepb.RegisterTraceServiceServer(server, srv)
+			go func() {
+				_ = server.Serve(ln)
+			}()
+			t.Cleanup(server.Stop)
+
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+				Endpoint: ln.Addr().String(),
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+			}
+			cfg.NumWorkers = 1
+			cfg.UserAgent = tt.userAgent
+			set := componenttest.NewNopExporterCreateSettings()
+			set.BuildInfo = component.BuildInfo{Description: "Custom Collector", Version: "v1.0.0"}
+			oce, err := newTracesExporter(context.Background(), cfg, set)
+			require.NoError(t, err)
+			require.NoError(t, oce.start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				assert.NoError(t, oce.shutdown(context.Background()))
+			})
+
+			assert.NoError(t, oce.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+			select {
+			case userAgent := <-srv.userAgents:
+				// gRPC appends its own user agent to the configured one
+				assert.True(t, strings.HasPrefix(userAgent, tt.expected+" grpc-go/"), userAgent)
+			case <-time.After(10 * time.Second):
+				t.Fatal("the receiver didn't observe the Export RPC")
+			}
+		})
+	}
+}
+
+// headersTraceServer and headersMetricsServer record the metadata of the Export RPCs they receive.
+type headersTraceServer struct {
+	agenttracepb.UnimplementedTraceServiceServer
+	headers chan metadata.MD
+}
+
+func (s *headersTraceServer) Export(stream agenttracepb.TraceService_ExportServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.headers <- md
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return nil
+		}
+	}
+}
+
+type headersMetricsServer struct {
+	agentmetricspb.UnimplementedMetricsServiceServer
+	headers chan metadata.MD
+}
+
+func (s *headersMetricsServer) Export(stream agentmetricspb.MetricsService_ExportServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.headers <- md
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return nil
+		}
+	}
+}
+
+func TestSend_Headers(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	headers := make(chan metadata.MD, 2)
+	server := grpc.NewServer()
+	agenttracepb.RegisterTraceServiceServer(server, &headersTraceServer{headers: headers})
+	agentmetricspb.RegisterMetricsServiceServer(server, &headersMetricsServer{headers: headers})
+	go func() {
+		_ = server.Serve(ln)
+	}()
+	t.Cleanup(server.Stop)
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		Headers: map[string]string{
+			"Authorization": "Bearer token",
+			"x-tenant":      "acme",
+		},
+	}
+	cfg.NumWorkers = 1
+
+	texp, err := newTracesExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, texp.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, texp.shutdown(context.Background()))
+	})
+	mexp, err := newMetricsExporter(context.Background(), cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, mexp.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, mexp.shutdown(context.Background()))
+	})
+
+	require.NoError(t, texp.pushTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	require.NoError(t, mexp.pushMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+	for i := 0; i < 2; i++ {
+		select {
+		case md := <-headers:
+			// gRPC metadata keys are lowercase
+			assert.Equal(t, []string{"Bearer token"}, md.Get("authorization"))
+			assert.Equal(t, []string{"acme"}, md.Get("x-tenant"))
+		case <-time.After(10 * time.Second):
+			t.Fatal("the receiver didn't observe the Export RPC")
+		}
+	}
+}
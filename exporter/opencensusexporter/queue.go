@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/opencensusexporter"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// queueTracker tracks the batches of a signal in the sending queue, from when they are added to
+// the queue until the first attempt to send them. The queue itself is owned by exporterhelper,
+// which keeps the context of a batch until it is sent, so the batches are marked in their context.
+type queueTracker struct {
+	mutators []tag.Mutator
+
+	mu   sync.Mutex
+	size int64
+}
+
+// queuedBatchKey is the context key of the queuedBatch.
+type queuedBatchKey struct{}
+
+// queuedBatch marks a batch added to the queue. dequeued is set once the batch is taken from it.
+type queuedBatch struct {
+	dequeued int32
+}
+
+func newQueueTracker(exporterName string, signal string) *queueTracker {
+	return &queueTracker{
+		mutators: []tag.Mutator{tag.Upsert(tagExporterName, exporterName), tag.Upsert(tagSignal, signal)},
+	}
+}
+
+// add records that a batch is being added to the queue, returning the context to consume it with.
+// It must be called before the batch is consumed, as it may be pushed before the call to consume returns.
+func (q *queueTracker) add(ctx context.Context) context.Context {
+	q.record(ctx, 1)
+	return context.WithValue(ctx, queuedBatchKey{}, &queuedBatch{})
+}
+
+// added records the outcome of adding the batch to the queue. A batch that was refused, e.g. as
+// the queue is full, isn't counted anymore.
+func (q *queueTracker) added(ctx context.Context, err error) {
+	if err != nil {
+		q.remove(ctx)
+		return
+	}
+	_ = stats.RecordWithTags(ctx, q.mutators, mEnqueuedBatches.M(1))
+}
+
+// pushed records that the batch is being sent. Only the first attempt to send a batch takes it
+// from the queue, the retries aren't counted.
+func (q *queueTracker) pushed(ctx context.Context) {
+	if q == nil {
+		return
+	}
+	if q.remove(ctx) {
+		_ = stats.RecordWithTags(ctx, q.mutators, mDequeuedBatches.M(1))
+	}
+}
+
+// remove removes the batch from the queue, returning whether it was still in the queue. Batches
+// without a mark, e.g. as they were restored from a persistent queue, were never counted.
+func (q *queueTracker) remove(ctx context.Context) bool {
+	batch, ok := ctx.Value(queuedBatchKey{}).(*queuedBatch)
+	if !ok || !atomic.CompareAndSwapInt32(&batch.dequeued, 0, 1) {
+		return false
+	}
+	q.record(ctx, -1)
+	return true
+}
+
+// record updates the number of batches in the queue by delta.
+func (q *queueTracker) record(ctx context.Context, delta int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.size += delta
+	_ = stats.RecordWithTags(ctx, q.mutators, mQueueBatches.M(q.size))
+}
+
+// queueTracesExporter records the traces added to the sending queue of the exporter.
+type queueTracesExporter struct {
+	component.TracesExporter
+	queue *queueTracker
+}
+
+func (e *queueTracesExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	ctx = e.queue.add(ctx)
+	err := e.TracesExporter.ConsumeTraces(ctx, td)
+	e.queue.added(ctx, err)
+	return err
+}
+
+// queueMetricsExporter records the metrics added to the sending queue of the exporter.
+type queueMetricsExporter struct {
+	component.MetricsExporter
+	queue *queueTracker
+}
+
+func (e *queueMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	ctx = e.queue.add(ctx)
+	err := e.MetricsExporter.ConsumeMetrics(ctx, md)
+	e.queue.added(ctx, err)
+	return err
+}
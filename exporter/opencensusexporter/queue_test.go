@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensusexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/opencensusreceiver"
+)
+
+func TestSendTraces_QueueMetrics(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	require.NoError(t, view.Register(MetricViews()...))
+	defer view.Unregister(MetricViews()...)
+
+	sink := new(consumertest.TracesSink)
+	rFactory := opencensusreceiver.NewFactory()
+	rCfg := rFactory.CreateDefaultConfig().(*opencensusreceiver.Config)
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	rCfg.GRPCServerSettings.NetAddr.Endpoint = endpoint
+	recv, err := rFactory.CreateTracesReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), rCfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, recv.Shutdown(context.Background()))
+	})
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.GRPCClientSettings = configgrpc.GRPCClientSettings{
+		Endpoint: endpoint,
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	cfg.QueueSettings.Enabled = true
+	cfg.QueueSettings.NumConsumers = 1
+	cfg.QueueSettings.QueueSize = 3
+	exp, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	// The queue is only consumed once the exporter is started, so the batches stay buffered.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	}
+	// The queue is full, the batch is refused and isn't counted.
+	assert.Error(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	assert.Equal(t, int64(3), queueMetricValue(t, vQueueBatches, "traces"))
+	assert.Equal(t, int64(3), queueMetricValue(t, vEnqueuedBatches, "traces"))
+	assert.Equal(t, int64(0), queueMetricValue(t, vDequeuedBatches, "traces"))
+
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	})
+	assert.Eventually(t, func() bool {
+		return len(sink.AllTraces()) == 3
+	}, 10*time.Second, 5*time.Millisecond)
+	assert.Equal(t, int64(0), queueMetricValue(t, vQueueBatches, "traces"))
+	assert.Equal(t, int64(3), queueMetricValue(t, vEnqueuedBatches, "traces"))
+	assert.Equal(t, int64(3), queueMetricValue(t, vDequeuedBatches, "traces"))
+	assert.Equal(t, int64(0), queueMetricValue(t, vEnqueuedBatches, "metrics"))
+}
+
+func TestQueueTracker_SameBatch(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	require.NoError(t, view.Register(MetricViews()...))
+	defer view.Unregister(MetricViews()...)
+
+	q := newQueueTracker("opencensus", "traces")
+	// The same batch consumed twice is queued twice, each time with its own context.
+	first := q.add(context.Background())
+	q.added(first, nil)
+	second := q.add(context.Background())
+	q.added(second, nil)
+	assert.Equal(t, int64(2), queueMetricValue(t, vQueueBatches, "traces"))
+
+	q.pushed(first)
+	assert.Equal(t, int64(1), queueMetricValue(t, vQueueBatches, "traces"))
+	q.pushed(second)
+	// A retry doesn't take the batch from the queue again.
+	q.pushed(second)
+	// A batch that was never counted, e.g. restored from a persistent queue, isn't taken from the queue.
+	q.pushed(context.Background())
+	assert.Equal(t, int64(0), queueMetricValue(t, vQueueBatches, "traces"))
+	assert.Equal(t, int64(2), queueMetricValue(t, vDequeuedBatches, "traces"))
+}
+
+func TestQueueTracker_Refused(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	require.NoError(t, view.Register(MetricViews()...))
+	defer view.Unregister(MetricViews()...)
+
+	q := newQueueTracker("opencensus", "traces")
+	ctx := q.add(context.Background())
+	q.added(ctx, errors.New("sending_queue is full"))
+	assert.Equal(t, int64(0), queueMetricValue(t, vQueueBatches, "traces"))
+	assert.Equal(t, int64(0), queueMetricValue(t, vEnqueuedBatches, "traces"))
+	assert.Equal(t, int64(0), queueMetricValue(t, vDequeuedBatches, "traces"))
+}
+
+// queueMetricValue returns the value recorded for v and signal, 0 if none was recorded.
+func queueMetricValue(t *testing.T, v *view.View, signal string) int64 {
+	rows, err := view.RetrieveData(v.Name)
+	require.NoError(t, err)
+	for _, row := range rows {
+		for _, tag := range row.Tags {
+			if tag.Key != tagSignal || tag.Value != signal {
+				continue
+			}
+			switch data := row.Data.(type) {
+			case *view.LastValueData:
+				return int64(data.Value)
+			case *view.SumData:
+				return int64(data.Value)
+			}
+		}
+	}
+	return 0
+}
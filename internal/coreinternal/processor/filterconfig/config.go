@@ -78,11 +78,13 @@ type MatchProperties struct {
 	// Config configures the matching patterns used when matching span properties.
 	filterset.Config `mapstructure:",squash"`
 
-	// Note: For spans, one of Services, SpanNames, Attributes, Resources or Libraries must be specified with a
-	// non-empty value for a valid configuration.
+	// Note: For spans, one of Services, SpanNames, SpanKinds, Names, Attributes, Resources, Libraries,
+	// OTTLConditions, ResourceSchemaURLs or ScopeSchemaURLs must be specified with a non-empty value for a
+	// valid configuration.
 
-	// For logs, one of LogNames, Attributes, Resources or Libraries must be specified with a
-	// non-empty value for a valid configuration.
+	// For logs, one of Names, Attributes, Resources, Libraries, LogBodies, LogSeverityTexts, LogSeverityNumber,
+	// OTTLConditions, ResourceSchemaURLs or ScopeSchemaURLs must be specified with a non-empty value for a
+	// valid configuration.
 
 	// For metrics, one of MetricNames, Expressions, or ResourceAttributes must be specified with a
 	// non-empty value for a valid configuration.
@@ -114,11 +116,16 @@ type MatchProperties struct {
 	MetricNames []string `mapstructure:"metric_names"`
 
 	// Attributes specifies the list of attributes to match against.
-	// All of these attributes must match exactly for a match to occur.
+	// All of these attributes must match exactly for a match to occur, unless AttributesMatch is "any".
 	// Only match_type=strict is allowed if "attributes" are specified.
 	// This is an optional field.
 	Attributes []Attribute `mapstructure:"attributes"`
 
+	// AttributesMatch specifies whether all the Attributes must match for a match to occur, with "all",
+	// or at least one of them, with "any". Defaults to "all".
+	// This is an optional field.
+	AttributesMatch AttributesMatch `mapstructure:"attributes_match"`
+
 	// Resources specify the list of items to match the resources against.
 	// A match occurs if the data's resources match at least one item in this list.
 	// This is an optional field.
@@ -133,12 +140,35 @@ type MatchProperties struct {
 	// A match occurs if the span's span kind matches at least one item in this list.
 	// This is an optional field
 	SpanKinds []string `mapstructure:"span_kinds"`
+
+	// Names specify the list of items to match the name of the data against: the span name for spans and
+	// the instrumentation scope name for logs.
+	// A match occurs if the name matches at least one item in this list.
+	// This is an optional field.
+	Names []string `mapstructure:"names"`
+
+	// OTTLConditions specify the list of OTTL boolean expressions to match the data against, e.g.
+	// `attributes["http.status_code"] >= 500`. The expressions are evaluated in the OTTL context of the
+	// data: the traces context for spans and the logs context for logs.
+	// A match occurs if at least one of the expressions is true.
+	// This is an optional field.
+	OTTLConditions []string `mapstructure:"ottl_conditions"`
+
+	// ResourceSchemaURLs specify the list of items to match the schema URL of the resource against.
+	// A match occurs if the schema URL matches at least one item in this list.
+	// This is an optional field.
+	ResourceSchemaURLs []string `mapstructure:"resource_schema_urls"`
+
+	// ScopeSchemaURLs specify the list of items to match the schema URL of the instrumentation scope against.
+	// A match occurs if the schema URL matches at least one item in this list.
+	// This is an optional field.
+	ScopeSchemaURLs []string `mapstructure:"scope_schema_urls"`
 }
 
 var (
-	ErrMissingRequiredField    = errors.New(`at least one of "attributes", "libraries",  or "resources" field must be specified`)
+	ErrMissingRequiredField    = errors.New(`at least one of "services", "span_names", "span_kinds", "names", "attributes", "libraries", "ottl_conditions", "resources", "resource_schema_urls" or "scope_schema_urls" field must be specified`)
 	ErrInvalidLogField         = errors.New("services, span_names, and span_kinds are not valid for log records")
-	ErrMissingRequiredLogField = errors.New(`at least one of "attributes", "libraries", "span_kinds", "resources", "log_bodies", "log_severity_texts" or "log_severity_number" field must be specified`)
+	ErrMissingRequiredLogField = errors.New(`at least one of "attributes", "libraries", "names", "ottl_conditions", "span_kinds", "resources", "resource_schema_urls", "scope_schema_urls", "log_bodies", "log_severity_texts" or "log_severity_number" field must be specified`)
 
 	spanKinds = map[string]bool{
 		ptrace.SpanKindInternal.String(): true,
@@ -149,6 +179,26 @@ var (
 	}
 )
 
+// AttributesMatch describes how the Attributes of MatchProperties are combined.
+type AttributesMatch string
+
+const (
+	// AttributesMatchAll requires all the attributes to match.
+	AttributesMatchAll AttributesMatch = "all"
+	// AttributesMatchAny requires at least one of the attributes to match.
+	AttributesMatchAny AttributesMatch = "any"
+)
+
+// Validate returns an error if the AttributesMatch is neither empty, "all" nor "any".
+func (am AttributesMatch) Validate() error {
+	switch am {
+	case "", AttributesMatchAll, AttributesMatchAny:
+		return nil
+	default:
+		return fmt.Errorf("attributes_match must be one of %q or %q, but is %q", AttributesMatchAll, AttributesMatchAny, am)
+	}
+}
+
 // ValidateForSpans validates properties for spans.
 func (mp *MatchProperties) ValidateForSpans() error {
 	if len(mp.LogBodies) > 0 {
@@ -164,7 +214,9 @@ func (mp *MatchProperties) ValidateForSpans() error {
 	}
 
 	if len(mp.Services) == 0 && len(mp.SpanNames) == 0 && len(mp.Attributes) == 0 &&
-		len(mp.Libraries) == 0 && len(mp.Resources) == 0 && len(mp.SpanKinds) == 0 {
+		len(mp.Libraries) == 0 && len(mp.Resources) == 0 && len(mp.SpanKinds) == 0 &&
+		len(mp.Names) == 0 && len(mp.OTTLConditions) == 0 &&
+		len(mp.ResourceSchemaURLs) == 0 && len(mp.ScopeSchemaURLs) == 0 {
 		return ErrMissingRequiredField
 	}
 
@@ -193,7 +245,8 @@ func (mp *MatchProperties) ValidateForLogs() error {
 	if len(mp.Attributes) == 0 && len(mp.Libraries) == 0 &&
 		len(mp.Resources) == 0 && len(mp.LogBodies) == 0 &&
 		len(mp.LogSeverityTexts) == 0 && mp.LogSeverityNumber == nil &&
-		len(mp.SpanKinds) == 0 {
+		len(mp.SpanKinds) == 0 && len(mp.Names) == 0 && len(mp.OTTLConditions) == 0 &&
+		len(mp.ResourceSchemaURLs) == 0 && len(mp.ScopeSchemaURLs) == 0 {
 		return ErrMissingRequiredLogField
 	}
 
@@ -208,6 +261,15 @@ type Attribute struct {
 	// Values specifies the value to match against.
 	// If it is not set, any value will match.
 	Value interface{} `mapstructure:"value"`
+
+	// Min and Max specify the inclusive bounds a numeric value, int or double, must be within.
+	// They can't be set with Value, and attributes of other types don't match.
+	Min *float64 `mapstructure:"min"`
+	Max *float64 `mapstructure:"max"`
+
+	// Negate inverts the match: the attribute matches if it is absent or, when Value is set,
+	// if its value doesn't match Value.
+	Negate bool `mapstructure:"negate"`
 }
 
 // InstrumentationLibrary specifies the instrumentation library and optional version to match against.
@@ -221,6 +283,9 @@ type InstrumentationLibrary struct {
 	//  <blank>  1       no
 	//  1        <blank> no
 	//  1        1       yes
+	// A version starting with a comparison operator is a range of semantic versions instead, made of
+	// space separated constraints that must all be satisfied, e.g. ">=1.2.0 <2.0.0". The supported
+	// operators are =, !=, >, >=, < and <=.
 	Version *string `mapstructure:"version"`
 }
 
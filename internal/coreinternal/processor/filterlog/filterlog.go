@@ -23,6 +23,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllogs"
 )
 
 // Matcher is an interface that allows matching a log record against a
@@ -31,7 +32,7 @@ import (
 //
 //	calling processors will always have the same logic.
 type Matcher interface {
-	MatchLogRecord(lr plog.LogRecord, resource pcommon.Resource, library pcommon.InstrumentationScope) bool
+	MatchLogRecord(lr plog.LogRecord, resource pcommon.Resource, library pcommon.InstrumentationScope, schemaURLs filtermatcher.SchemaURLs) bool
 }
 
 // propertiesMatcher allows matching a log record against various log record properties.
@@ -46,6 +47,9 @@ type propertiesMatcher struct {
 
 	// matcher for severity number
 	severityNumberMatcher Matcher
+
+	// OTTL conditions to evaluate
+	conditions filtermatcher.OTTLConditions[ottllogs.TransformContext]
 }
 
 // NewMatcher creates a LogRecord Matcher that matches based on the given MatchProperties.
@@ -83,11 +87,20 @@ func NewMatcher(mp *filterconfig.MatchProperties) (Matcher, error) {
 		severityNumberMatcher = newSeverityNumberMatcher(mp.LogSeverityNumber.Min, mp.LogSeverityNumber.MatchUndefined)
 	}
 
+	var conditions filtermatcher.OTTLConditions[ottllogs.TransformContext]
+	if len(mp.OTTLConditions) > 0 {
+		conditions, err = filtermatcher.NewOTTLConditions(mp.OTTLConditions, ottllogs.NewParser)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &propertiesMatcher{
 		PropertiesMatcher:     rm,
 		bodyFilters:           bodyFS,
 		severityTextFilters:   severitytextFS,
 		severityNumberMatcher: severityNumberMatcher,
+		conditions:            conditions,
 	}, nil
 }
 
@@ -99,16 +112,19 @@ func NewMatcher(mp *filterconfig.MatchProperties) (Matcher, error) {
 // At least one of log record names or attributes must be specified. It is
 // supported to have more than one of these specified, and all specified must
 // evaluate to true for a match to occur.
-func (mp *propertiesMatcher) MatchLogRecord(lr plog.LogRecord, resource pcommon.Resource, library pcommon.InstrumentationScope) bool {
+func (mp *propertiesMatcher) MatchLogRecord(lr plog.LogRecord, resource pcommon.Resource, library pcommon.InstrumentationScope, schemaURLs filtermatcher.SchemaURLs) bool {
 	if lr.Body().Type() == pcommon.ValueTypeStr && mp.bodyFilters != nil && !mp.bodyFilters.Matches(lr.Body().Str()) {
 		return false
 	}
 	if mp.severityTextFilters != nil && !mp.severityTextFilters.Matches(lr.SeverityText()) {
 		return false
 	}
-	if mp.severityNumberMatcher != nil && !mp.severityNumberMatcher.MatchLogRecord(lr, resource, library) {
+	if mp.severityNumberMatcher != nil && !mp.severityNumberMatcher.MatchLogRecord(lr, resource, library, schemaURLs) {
+		return false
+	}
+	if mp.conditions != nil && !mp.conditions.Match(ottllogs.NewTransformContext(lr, library, resource)) {
 		return false
 	}
 
-	return mp.PropertiesMatcher.Match(lr.Attributes(), resource, library)
+	return mp.PropertiesMatcher.Match(lr.Attributes(), resource, library, library.Name(), schemaURLs)
 }
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
 )
 
@@ -124,6 +125,19 @@ func TestLogRecord_Matching_False(t *testing.T) {
 				LogSeverityTexts: []string{"debug.*"},
 			},
 		},
+		{
+			name: "name_dont_match",
+			properties: &filterconfig.MatchProperties{
+				Config: *createConfig(filterset.Strict),
+				Names:  []string{"other"},
+			},
+		},
+		{
+			name: "ottl_condition_dont_match",
+			properties: &filterconfig.MatchProperties{
+				OTTLConditions: []string{`severity_number >= SEVERITY_NUMBER_INFO`},
+			},
+		},
 		{
 			name: "log_min_severity_trace_dont_match",
 			properties: &filterconfig.MatchProperties{
@@ -138,13 +152,16 @@ func TestLogRecord_Matching_False(t *testing.T) {
 	lr := plog.NewLogRecord()
 	lr.SetSeverityNumber(plog.SeverityNumberTrace)
 
+	library := pcommon.NewInstrumentationScope()
+	library.SetName("scope")
+
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			matcher, err := NewMatcher(tc.properties)
 			assert.Nil(t, err)
 			require.NotNil(t, matcher)
 
-			assert.False(t, matcher.MatchLogRecord(lr, pcommon.Resource{}, pcommon.InstrumentationScope{}))
+			assert.False(t, matcher.MatchLogRecord(lr, pcommon.NewResource(), library, filtermatcher.SchemaURLs{}))
 		})
 	}
 }
@@ -170,6 +187,19 @@ func TestLogRecord_Matching_True(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "name_regex_match",
+			properties: &filterconfig.MatchProperties{
+				Config: *createConfig(filterset.Regexp),
+				Names:  []string{"sc.*"},
+			},
+		},
+		{
+			name: "ottl_condition_match",
+			properties: &filterconfig.MatchProperties{
+				OTTLConditions: []string{`attributes["abc"] == "def" and severity_text == "debug"`},
+			},
+		},
 		{
 			name: "log_body_regexp_match",
 			properties: &filterconfig.MatchProperties{
@@ -201,6 +231,9 @@ func TestLogRecord_Matching_True(t *testing.T) {
 	lr.SetSeverityText("debug")
 	lr.SetSeverityNumber(plog.SeverityNumberDebug)
 
+	library := pcommon.NewInstrumentationScope()
+	library.SetName("scope")
+
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			mp, err := NewMatcher(tc.properties)
@@ -208,7 +241,7 @@ func TestLogRecord_Matching_True(t *testing.T) {
 			require.NotNil(t, mp)
 
 			assert.NotNil(t, lr)
-			assert.True(t, mp.MatchLogRecord(lr, pcommon.Resource{}, pcommon.InstrumentationScope{}))
+			assert.True(t, mp.MatchLogRecord(lr, pcommon.NewResource(), library, filtermatcher.SchemaURLs{}))
 		})
 	}
 }
@@ -17,6 +17,8 @@ package filterlog // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 )
 
 // severtiyNumberMatcher is a Matcher that matches if the input log record has a severity higher than
@@ -33,7 +35,7 @@ func newSeverityNumberMatcher(minSeverity plog.SeverityNumber, matchUndefined bo
 	}
 }
 
-func (snm severityNumberMatcher) MatchLogRecord(lr plog.LogRecord, _ pcommon.Resource, _ pcommon.InstrumentationScope) bool {
+func (snm severityNumberMatcher) MatchLogRecord(lr plog.LogRecord, _ pcommon.Resource, _ pcommon.InstrumentationScope, _ filtermatcher.SchemaURLs) bool {
 	// behavior on SeverityNumberUNDEFINED is explicitly defined by matchUndefined
 	if lr.SeverityNumber() == plog.SeverityNumberUnspecified {
 		return snm.matchUndefined
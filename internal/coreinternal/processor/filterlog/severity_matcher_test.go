@@ -20,6 +20,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 )
 
 func TestSeverityMatcher_MatchLogRecord(t *testing.T) {
@@ -90,7 +92,7 @@ func TestSeverityMatcher_MatchLogRecord(t *testing.T) {
 			lr := plog.NewLogRecord()
 			lr.SetSeverityNumber(tc.inputSeverity)
 
-			require.Equal(t, tc.matches, matcher.MatchLogRecord(lr, r, i))
+			require.Equal(t, tc.matches, matcher.MatchLogRecord(lr, r, i, filtermatcher.SchemaURLs{}))
 		})
 	}
 }
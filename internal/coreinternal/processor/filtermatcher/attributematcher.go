@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
@@ -35,6 +36,13 @@ type AttributeMatcher struct {
 	AttributeValue *pcommon.Value
 	// StringFilter is needed to match against a regular expression
 	StringFilter filterset.FilterSet
+	// Negate inverts the match, so that an absent attribute or a different value matches.
+	Negate bool
+	// CaseInsensitive makes a string AttributeValue match string attributes regardless of the case.
+	CaseInsensitive bool
+	// Min and Max are the inclusive bounds of a numeric value, if not nil.
+	Min *float64
+	Max *float64
 }
 
 var errUnexpectedAttributeType = errors.New("unexpected attribute type")
@@ -49,7 +57,18 @@ func NewAttributesMatcher(config filterset.Config, attributes []filterconfig.Att
 		}
 
 		entry := AttributeMatcher{
-			Key: attribute.Key,
+			Key:    attribute.Key,
+			Negate: attribute.Negate,
+			Min:    attribute.Min,
+			Max:    attribute.Max,
+		}
+		if attribute.Min != nil || attribute.Max != nil {
+			if attribute.Value != nil {
+				return nil, fmt.Errorf("%q can't have both a value and min or max", attribute.Key)
+			}
+			if attribute.Min != nil && attribute.Max != nil && *attribute.Min > *attribute.Max {
+				return nil, fmt.Errorf("min of %q must not be greater than max", attribute.Key)
+			}
 		}
 		if attribute.Value != nil {
 			val, err := filterhelper.NewAttributeValueRaw(attribute.Value)
@@ -73,6 +92,7 @@ func NewAttributesMatcher(config filterset.Config, attributes []filterconfig.Att
 				entry.StringFilter = filter
 			case filterset.Strict:
 				entry.AttributeValue = &val
+				entry.CaseInsensitive = config.CaseInsensitive
 			default:
 				return nil, filterset.NewUnrecognizedMatchTypeError(config.MatchType)
 
@@ -91,33 +111,67 @@ func (ma AttributesMatcher) Match(attrs pcommon.Map) bool {
 		return true
 	}
 
-	// At this point, it is expected of the span/log to have attributes because of
-	// len(ma) != 0. This means for spans/logs with no attributes, it does not match.
-	if attrs.Len() == 0 {
-		return false
+	// Check that all expected properties are set, or not set for the negated ones.
+	for _, property := range ma {
+		if property.matches(attrs) == property.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchAny matches attributes specification against a span/log, requiring only one of the
+// properties to match. If there are no attributes to match against, the span/log matches.
+func (ma AttributesMatcher) MatchAny(attrs pcommon.Map) bool {
+	if len(ma) == 0 {
+		return true
 	}
 
-	// Check that all expected properties are set.
 	for _, property := range ma {
-		attr, exist := attrs.Get(property.Key)
-		if !exist {
-			return false
+		if property.matches(attrs) != property.Negate {
+			return true
 		}
+	}
+	return false
+}
 
-		if property.StringFilter != nil {
-			value, err := attributeStringValue(attr)
-			if err != nil || !property.StringFilter.Matches(value) {
-				return false
-			}
-		} else if property.AttributeValue != nil {
-			if !attr.Equal(*property.AttributeValue) {
-				return false
-			}
+// matches returns whether the attribute is present with the expected value, regardless of Negate.
+func (m AttributeMatcher) matches(attrs pcommon.Map) bool {
+	attr, exist := attrs.Get(m.Key)
+	if !exist {
+		return false
+	}
+
+	if m.Min != nil || m.Max != nil {
+		return m.inRange(attr)
+	}
+	if m.StringFilter != nil {
+		value, err := attributeStringValue(attr)
+		return err == nil && m.StringFilter.Matches(value)
+	}
+	if m.AttributeValue != nil {
+		if m.CaseInsensitive && attr.Type() == pcommon.ValueTypeStr && m.AttributeValue.Type() == pcommon.ValueTypeStr {
+			return strings.ToLower(attr.Str()) == strings.ToLower(m.AttributeValue.Str())
 		}
+		return attr.Equal(*m.AttributeValue)
 	}
 	return true
 }
 
+// inRange returns whether the attribute is numeric and within Min and Max.
+func (m AttributeMatcher) inRange(attr pcommon.Value) bool {
+	var value float64
+	switch attr.Type() {
+	case pcommon.ValueTypeInt:
+		value = float64(attr.Int())
+	case pcommon.ValueTypeDouble:
+		value = attr.Double()
+	default:
+		return false
+	}
+	return (m.Min == nil || value >= *m.Min) && (m.Max == nil || value <= *m.Max)
+}
+
 func attributeStringValue(attr pcommon.Value) (string, error) {
 	switch attr.Type() {
 	case pcommon.ValueTypeStr:
@@ -28,20 +28,50 @@ type instrumentationLibraryMatcher struct {
 	Version filterset.FilterSet
 }
 
+// SchemaURLs holds the schema URLs of the resource and of the instrumentation scope of the matched data,
+// as found in e.g. ptrace.ResourceSpans and ptrace.ScopeSpans.
+type SchemaURLs struct {
+	Resource string
+	Scope    string
+}
+
 // PropertiesMatcher allows matching a span against various span properties.
 type PropertiesMatcher struct {
+	// Names of the data to compare against
+	names filterset.FilterSet
+
 	// Instrumentation libraries to compare against
 	libraries []instrumentationLibraryMatcher
 
 	// The attribute values are stored in the internal format.
 	attributes AttributesMatcher
 
+	// Whether a single attribute matching is enough
+	matchAnyAttribute bool
+
 	// The attribute values are stored in the internal format.
 	resources AttributesMatcher
+
+	// Schema URLs of the resource and of the instrumentation scope to compare against
+	resourceSchemaURLs filterset.FilterSet
+	scopeSchemaURLs    filterset.FilterSet
 }
 
 // NewMatcher creates a span Matcher that matches based on the given MatchProperties.
 func NewMatcher(mp *filterconfig.MatchProperties) (PropertiesMatcher, error) {
+	if err := mp.AttributesMatch.Validate(); err != nil {
+		return PropertiesMatcher{}, err
+	}
+
+	var nm filterset.FilterSet
+	if len(mp.Names) > 0 {
+		var err error
+		nm, err = filterset.CreateFilterSet(mp.Names, &mp.Config)
+		if err != nil {
+			return PropertiesMatcher{}, fmt.Errorf("error creating name filters: %w", err)
+		}
+	}
+
 	var lm []instrumentationLibraryMatcher
 	for _, library := range mp.Libraries {
 		name, err := filterset.CreateFilterSet([]string{library.Name}, &mp.Config)
@@ -50,7 +80,15 @@ func NewMatcher(mp *filterconfig.MatchProperties) (PropertiesMatcher, error) {
 		}
 
 		var version filterset.FilterSet
-		if library.Version != nil {
+		switch {
+		case library.Version == nil:
+		case isVersionRange(*library.Version):
+			versions, err := newVersionRange(*library.Version)
+			if err != nil {
+				return PropertiesMatcher{}, fmt.Errorf("error creating library version range: %w", err)
+			}
+			version = versions
+		default:
 			filter, err := filterset.CreateFilterSet([]string{*library.Version}, &mp.Config)
 			if err != nil {
 				return PropertiesMatcher{}, fmt.Errorf("error creating library version filters: %w", err)
@@ -78,15 +116,48 @@ func NewMatcher(mp *filterconfig.MatchProperties) (PropertiesMatcher, error) {
 		}
 	}
 
+	var rsm filterset.FilterSet
+	if len(mp.ResourceSchemaURLs) > 0 {
+		rsm, err = filterset.CreateFilterSet(mp.ResourceSchemaURLs, &mp.Config)
+		if err != nil {
+			return PropertiesMatcher{}, fmt.Errorf("error creating resource schema URL filters: %w", err)
+		}
+	}
+
+	var ssm filterset.FilterSet
+	if len(mp.ScopeSchemaURLs) > 0 {
+		ssm, err = filterset.CreateFilterSet(mp.ScopeSchemaURLs, &mp.Config)
+		if err != nil {
+			return PropertiesMatcher{}, fmt.Errorf("error creating scope schema URL filters: %w", err)
+		}
+	}
+
 	return PropertiesMatcher{
-		libraries:  lm,
-		attributes: am,
-		resources:  rm,
+		names:              nm,
+		libraries:          lm,
+		attributes:         am,
+		matchAnyAttribute:  mp.AttributesMatch == filterconfig.AttributesMatchAny,
+		resources:          rm,
+		resourceSchemaURLs: rsm,
+		scopeSchemaURLs:    ssm,
 	}, nil
 }
 
-// Match matches a span or log to a set of properties.
-func (mp *PropertiesMatcher) Match(attributes pcommon.Map, resource pcommon.Resource, library pcommon.InstrumentationScope) bool {
+// Match matches a span or log to a set of properties. The name is the name of the data matched
+// against the names, e.g. the span name.
+func (mp *PropertiesMatcher) Match(attributes pcommon.Map, resource pcommon.Resource, library pcommon.InstrumentationScope, name string, schemaURLs SchemaURLs) bool {
+	if mp.names != nil && !mp.names.Matches(name) {
+		return false
+	}
+
+	if mp.resourceSchemaURLs != nil && !mp.resourceSchemaURLs.Matches(schemaURLs.Resource) {
+		return false
+	}
+
+	if mp.scopeSchemaURLs != nil && !mp.scopeSchemaURLs.Matches(schemaURLs.Scope) {
+		return false
+	}
+
 	for _, matcher := range mp.libraries {
 		if !matcher.Name.Matches(library.Name()) {
 			return false
@@ -100,5 +171,8 @@ func (mp *PropertiesMatcher) Match(attributes pcommon.Map, resource pcommon.Reso
 		return false
 	}
 
+	if mp.matchAnyAttribute {
+		return mp.attributes.MatchAny(attributes)
+	}
 	return mp.attributes.Match(attributes)
 }
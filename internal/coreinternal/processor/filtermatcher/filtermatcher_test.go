@@ -34,6 +34,9 @@ func createConfig(matchType filterset.MatchType) *filterset.Config {
 
 func Test_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 	version := "["
+	versionRange := ">=1.2.0 <two"
+	minValue := 2.0
+	maxValue := 1.0
 	testcases := []struct {
 		name        string
 		property    filterconfig.MatchProperties
@@ -75,6 +78,55 @@ func Test_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 			},
 			errorString: "error creating resource filters: error parsing regexp: missing closing ]: `[`",
 		},
+		{
+			name: "invalid_regexp_pattern_name",
+			property: filterconfig.MatchProperties{
+				Config: *createConfig(filterset.Regexp),
+				Names:  []string{"["},
+			},
+			errorString: "error creating name filters: error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			name: "invalid_attributes_match",
+			property: filterconfig.MatchProperties{
+				Config:          *createConfig(filterset.Strict),
+				Attributes:      []filterconfig.Attribute{{Key: "key"}},
+				AttributesMatch: "some",
+			},
+			errorString: `attributes_match must be one of "all" or "any", but is "some"`,
+		},
+		{
+			name: "attribute_value_and_min",
+			property: filterconfig.MatchProperties{
+				Config:     *createConfig(filterset.Strict),
+				Attributes: []filterconfig.Attribute{{Key: "key", Value: 1, Min: &minValue}},
+			},
+			errorString: `error creating attribute filters: "key" can't have both a value and min or max`,
+		},
+		{
+			name: "attribute_min_greater_than_max",
+			property: filterconfig.MatchProperties{
+				Config:     *createConfig(filterset.Strict),
+				Attributes: []filterconfig.Attribute{{Key: "key", Min: &minValue, Max: &maxValue}},
+			},
+			errorString: `error creating attribute filters: min of "key" must not be greater than max`,
+		},
+		{
+			name: "invalid_regexp_pattern_resource_schema_url",
+			property: filterconfig.MatchProperties{
+				Config:             *createConfig(filterset.Regexp),
+				ResourceSchemaURLs: []string{"["},
+			},
+			errorString: "error creating resource schema URL filters: error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			name: "invalid_regexp_pattern_scope_schema_url",
+			property: filterconfig.MatchProperties{
+				Config:          *createConfig(filterset.Regexp),
+				ScopeSchemaURLs: []string{"["},
+			},
+			errorString: "error creating scope schema URL filters: error parsing regexp: missing closing ]: `[`",
+		},
 		{
 			name: "invalid_regexp_pattern_library_name",
 			property: filterconfig.MatchProperties{
@@ -91,6 +143,14 @@ func Test_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 			},
 			errorString: "error creating library version filters: error parsing regexp: missing closing ]: `[`",
 		},
+		{
+			name: "invalid_library_version_range",
+			property: filterconfig.MatchProperties{
+				Config:    *createConfig(filterset.Strict),
+				Libraries: []filterconfig.InstrumentationLibrary{{Name: "lib", Version: &versionRange}},
+			},
+			errorString: `error creating library version range: invalid version range ">=1.2.0 <two": "two" is not a semantic version`,
+		},
 		{
 			name: "empty_key_name_in_attributes_list",
 			property: filterconfig.MatchProperties{
@@ -120,6 +180,13 @@ func Test_Matching_False(t *testing.T) {
 		name       string
 		properties *filterconfig.MatchProperties
 	}{
+		{
+			name: "wrong_name",
+			properties: &filterconfig.MatchProperties{
+				Config: *createConfig(filterset.Strict),
+				Names:  []string{"wrong"},
+			},
+		},
 		{
 			name: "wrong_library_name",
 			properties: &filterconfig.MatchProperties{
@@ -220,7 +287,7 @@ func Test_Matching_False(t *testing.T) {
 			require.NoError(t, err)
 			assert.NotNil(t, matcher)
 
-			assert.False(t, matcher.Match(attrs, resource("wrongSvc"), library))
+			assert.False(t, matcher.Match(attrs, resource("wrongSvc"), library, "name", SchemaURLs{}))
 		})
 	}
 }
@@ -240,7 +307,7 @@ func Test_MatchingCornerCases(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, mp)
 
-	assert.False(t, mp.Match(pcommon.NewMap(), resource("svcA"), pcommon.NewInstrumentationScope()))
+	assert.False(t, mp.Match(pcommon.NewMap(), resource("svcA"), pcommon.NewInstrumentationScope(), "", SchemaURLs{}))
 }
 
 func Test_Matching_True(t *testing.T) {
@@ -250,6 +317,20 @@ func Test_Matching_True(t *testing.T) {
 		name       string
 		properties *filterconfig.MatchProperties
 	}{
+		{
+			name: "name_exact_match",
+			properties: &filterconfig.MatchProperties{
+				Config: *createConfig(filterset.Strict),
+				Names:  []string{"other", "name"},
+			},
+		},
+		{
+			name: "name_regex_match",
+			properties: &filterconfig.MatchProperties{
+				Config: *createConfig(filterset.Regexp),
+				Names:  []string{"^na.*"},
+			},
+		},
 		{
 			name: "library_match",
 			properties: &filterconfig.MatchProperties{
@@ -382,7 +463,450 @@ func Test_Matching_True(t *testing.T) {
 			require.NoError(t, err)
 			assert.NotNil(t, mp)
 
-			assert.True(t, mp.Match(attrs, resource, library))
+			assert.True(t, mp.Match(attrs, resource, library, "name", SchemaURLs{}))
+		})
+	}
+}
+
+func Test_Matching_LibraryVersionRange(t *testing.T) {
+	testcases := []struct {
+		name     string
+		version  string
+		matching []string
+		other    []string
+	}{
+		{
+			name:     "bounded_range",
+			version:  ">=1.2.0 <2.0.0",
+			matching: []string{"1.2.0", "v1.2.0", "1.10.3", "1.99.99"},
+			other:    []string{"1.1.9", "2.0.0", "1.2.0-beta", "v3", "dev", ""},
+		},
+		{
+			name:     "operator_separated_from_version",
+			version:  "> 0.30 <= 0.35.1",
+			matching: []string{"0.30.1", "0.35.1", "0.35.1+build.5"},
+			other:    []string{"0.30.0", "0.35.2"},
+		},
+		{
+			name:     "exact_version",
+			version:  "=1.0.0",
+			matching: []string{"1.0.0", "1"},
+			other:    []string{"1.0.1", "1.0.0-alpha"},
+		},
+		{
+			name:     "excluded_version",
+			version:  ">=1.0.0 !=1.3.2",
+			matching: []string{"1.0.0", "1.3.1", "1.3.3"},
+			other:    []string{"1.3.2", "0.9.0"},
+		},
+		{
+			name:     "pre_releases",
+			version:  ">=1.0.0-alpha.2 <1.0.0",
+			matching: []string{"1.0.0-alpha.2", "1.0.0-alpha.10", "1.0.0-beta", "1.0.0-rc.1"},
+			other:    []string{"1.0.0-alpha.1", "1.0.0-alpha", "1.0.0"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// the range syntax is used regardless of the match type
+			for _, matchType := range []filterset.MatchType{filterset.Strict, filterset.Regexp} {
+				version := tc.version
+				mp, err := NewMatcher(&filterconfig.MatchProperties{
+					Config:    *createConfig(matchType),
+					Libraries: []filterconfig.InstrumentationLibrary{{Name: "lib", Version: &version}},
+				})
+				require.NoError(t, err)
+
+				library := pcommon.NewInstrumentationScope()
+				library.SetName("lib")
+				for _, v := range tc.matching {
+					library.SetVersion(v)
+					assert.True(t, mp.Match(pcommon.NewMap(), resource("svcA"), library, "", SchemaURLs{}), "version %q should match", v)
+				}
+				for _, v := range tc.other {
+					library.SetVersion(v)
+					assert.False(t, mp.Match(pcommon.NewMap(), resource("svcA"), library, "", SchemaURLs{}), "version %q should not match", v)
+				}
+			}
+		})
+	}
+}
+
+func Test_Matching_AttributeRanges(t *testing.T) {
+	sizeMin := 1048576.0
+	ratioMax := 0.5
+	statusMin, statusMax := 500.0, 599.0
+	testcases := []struct {
+		name       string
+		matchType  filterset.MatchType
+		attributes []filterconfig.Attribute
+		matching   []map[string]interface{}
+		other      []map[string]interface{}
+	}{
+		{
+			name:      "min",
+			matchType: filterset.Strict,
+			attributes: []filterconfig.Attribute{
+				{Key: "http.response_size", Min: &sizeMin},
+			},
+			matching: []map[string]interface{}{
+				{"http.response_size": 1048576},
+				{"http.response_size": 2097152},
+				{"http.response_size": 1048576.5},
+			},
+			other: []map[string]interface{}{
+				{"http.response_size": 1024},
+				{"http.response_size": 1048575.9},
+				{"http.response_size": "2097152"},
+				{"http.response_size": true},
+				{},
+			},
+		},
+		{
+			name:      "max",
+			matchType: filterset.Regexp,
+			attributes: []filterconfig.Attribute{
+				{Key: "ratio", Max: &ratioMax},
+			},
+			matching: []map[string]interface{}{
+				{"ratio": 0.5},
+				{"ratio": -1},
+				{"ratio": 0},
+			},
+			other: []map[string]interface{}{
+				{"ratio": 0.51},
+				{"ratio": 1},
+				{"ratio": "0.1"},
+			},
+		},
+		{
+			name:      "min_and_max",
+			matchType: filterset.Strict,
+			attributes: []filterconfig.Attribute{
+				{Key: "http.status_code", Min: &statusMin, Max: &statusMax},
+			},
+			matching: []map[string]interface{}{
+				{"http.status_code": 500},
+				{"http.status_code": 503},
+				{"http.status_code": 599},
+			},
+			other: []map[string]interface{}{
+				{"http.status_code": 404},
+				{"http.status_code": 600},
+			},
+		},
+		{
+			name:      "negated",
+			matchType: filterset.Strict,
+			attributes: []filterconfig.Attribute{
+				{Key: "http.response_size", Min: &sizeMin, Negate: true},
+			},
+			matching: []map[string]interface{}{
+				{"http.response_size": 1024},
+				{"http.response_size": "2097152"},
+				{},
+			},
+			other: []map[string]interface{}{
+				{"http.response_size": 2097152},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mp, err := NewMatcher(&filterconfig.MatchProperties{
+				Config:     *createConfig(tc.matchType),
+				Attributes: tc.attributes,
+			})
+			require.NoError(t, err)
+
+			for _, raw := range tc.matching {
+				attrs := pcommon.NewMap()
+				attrs.FromRaw(raw)
+				assert.True(t, mp.Match(attrs, resource("svcA"), pcommon.NewInstrumentationScope(), "", SchemaURLs{}), "%v should match", raw)
+			}
+			for _, raw := range tc.other {
+				attrs := pcommon.NewMap()
+				attrs.FromRaw(raw)
+				assert.False(t, mp.Match(attrs, resource("svcA"), pcommon.NewInstrumentationScope(), "", SchemaURLs{}), "%v should not match", raw)
+			}
+		})
+	}
+}
+
+func Test_Matching_AttributesMatch(t *testing.T) {
+	attributes := []filterconfig.Attribute{
+		{Key: "env", Value: "dev"},
+		{Key: "debug", Value: true},
+		{Key: "internal", Negate: true},
+	}
+	testcases := []struct {
+		name            string
+		attributesMatch filterconfig.AttributesMatch
+		matching        []map[string]interface{}
+		other           []map[string]interface{}
+	}{
+		{
+			name: "default",
+			matching: []map[string]interface{}{
+				{"env": "dev", "debug": true},
+			},
+			other: []map[string]interface{}{
+				{"env": "dev", "debug": true, "internal": true},
+				{"env": "dev", "debug": false},
+				{"env": "prod"},
+			},
+		},
+		{
+			name:            "all",
+			attributesMatch: filterconfig.AttributesMatchAll,
+			matching: []map[string]interface{}{
+				{"env": "dev", "debug": true},
+			},
+			other: []map[string]interface{}{
+				{"env": "dev", "debug": true, "internal": true},
+				{"env": "dev", "debug": false},
+				{"env": "prod"},
+			},
+		},
+		{
+			name:            "any",
+			attributesMatch: filterconfig.AttributesMatchAny,
+			matching: []map[string]interface{}{
+				{"env": "dev", "debug": true},
+				{"env": "dev", "internal": true},
+				{"debug": true, "internal": true},
+				{"env": "prod"},
+			},
+			other: []map[string]interface{}{
+				{"env": "prod", "debug": false, "internal": true},
+				{"internal": true},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mp, err := NewMatcher(&filterconfig.MatchProperties{
+				Config:          *createConfig(filterset.Strict),
+				Attributes:      attributes,
+				AttributesMatch: tc.attributesMatch,
+			})
+			require.NoError(t, err)
+
+			for _, raw := range tc.matching {
+				attrs := pcommon.NewMap()
+				attrs.FromRaw(raw)
+				assert.True(t, mp.Match(attrs, resource("svcA"), pcommon.NewInstrumentationScope(), "", SchemaURLs{}), "%v should match", raw)
+			}
+			for _, raw := range tc.other {
+				attrs := pcommon.NewMap()
+				attrs.FromRaw(raw)
+				assert.False(t, mp.Match(attrs, resource("svcA"), pcommon.NewInstrumentationScope(), "", SchemaURLs{}), "%v should not match", raw)
+			}
+		})
+	}
+}
+
+func Test_Matching_NegatedAttributes(t *testing.T) {
+	testcases := []struct {
+		name       string
+		matchType  filterset.MatchType
+		attributes []filterconfig.Attribute
+		matching   []map[string]interface{}
+		other      []map[string]interface{}
+	}{
+		{
+			name:      "present_and_not_value",
+			matchType: filterset.Strict,
+			attributes: []filterconfig.Attribute{
+				{Key: "env"},
+				{Key: "debug", Value: true, Negate: true},
+			},
+			matching: []map[string]interface{}{
+				{"env": "prod"},
+				{"env": "prod", "debug": false},
+				{"env": "prod", "debug": "true"},
+			},
+			other: []map[string]interface{}{
+				{"env": "prod", "debug": true},
+				{"debug": false},
+				{},
+			},
+		},
+		{
+			name:      "absent",
+			matchType: filterset.Strict,
+			attributes: []filterconfig.Attribute{
+				{Key: "debug", Negate: true},
+			},
+			matching: []map[string]interface{}{
+				{},
+				{"env": "prod"},
+			},
+			other: []map[string]interface{}{
+				{"debug": true},
+				{"debug": false},
+			},
+		},
+		{
+			name:      "not_regexp",
+			matchType: filterset.Regexp,
+			attributes: []filterconfig.Attribute{
+				{Key: "http.url", Value: "^https://internal\\.", Negate: true},
+			},
+			matching: []map[string]interface{}{
+				{"http.url": "https://example.com"},
+				{"http.url": 1},
+				{},
+			},
+			other: []map[string]interface{}{
+				{"http.url": "https://internal.example.com"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mp, err := NewMatcher(&filterconfig.MatchProperties{
+				Config:     *createConfig(tc.matchType),
+				Attributes: tc.attributes,
+			})
+			require.NoError(t, err)
+
+			for _, raw := range tc.matching {
+				attrs := pcommon.NewMap()
+				attrs.FromRaw(raw)
+				assert.True(t, mp.Match(attrs, resource("svcA"), pcommon.NewInstrumentationScope(), "", SchemaURLs{}), "%v should match", raw)
+			}
+			for _, raw := range tc.other {
+				attrs := pcommon.NewMap()
+				attrs.FromRaw(raw)
+				assert.False(t, mp.Match(attrs, resource("svcA"), pcommon.NewInstrumentationScope(), "", SchemaURLs{}), "%v should not match", raw)
+			}
+		})
+	}
+}
+
+func Test_Matching_CaseInsensitive(t *testing.T) {
+	version := "V1.0"
+	mp, err := NewMatcher(&filterconfig.MatchProperties{
+		Config: filterset.Config{
+			MatchType:       filterset.Strict,
+			CaseInsensitive: true,
+		},
+		Attributes: []filterconfig.Attribute{
+			{Key: "http.method", Value: "get"},
+			{Key: "http.status_code", Value: 200},
+		},
+		Libraries: []filterconfig.InstrumentationLibrary{
+			{Name: "Lib", Version: &version},
+		},
+	})
+	require.NoError(t, err)
+
+	library := pcommon.NewInstrumentationScope()
+	library.SetName("lib")
+	library.SetVersion("v1.0")
+
+	attrs := pcommon.NewMap()
+	attrs.FromRaw(map[string]interface{}{"http.method": "GET", "http.status_code": 200})
+	assert.True(t, mp.Match(attrs, resource("svcA"), library, "", SchemaURLs{}))
+
+	attrs.PutStr("http.method", "POST")
+	assert.False(t, mp.Match(attrs, resource("svcA"), library, "", SchemaURLs{}))
+
+	// only strings are compared regardless of the case
+	attrs.PutStr("http.method", "Get")
+	attrs.PutStr("http.status_code", "200")
+	assert.False(t, mp.Match(attrs, resource("svcA"), library, "", SchemaURLs{}))
+
+	library.SetName("other")
+	attrs.PutInt("http.status_code", 200)
+	assert.False(t, mp.Match(attrs, resource("svcA"), library, "", SchemaURLs{}))
+}
+
+func Test_Matching_SchemaURLs(t *testing.T) {
+	testcases := []struct {
+		name     string
+		config   filterconfig.MatchProperties
+		matching []SchemaURLs
+		other    []SchemaURLs
+	}{
+		{
+			name: "resource_strict",
+			config: filterconfig.MatchProperties{
+				Config:             *createConfig(filterset.Strict),
+				ResourceSchemaURLs: []string{"https://opentelemetry.io/schemas/1.9.0"},
+			},
+			matching: []SchemaURLs{
+				{Resource: "https://opentelemetry.io/schemas/1.9.0"},
+				{Resource: "https://opentelemetry.io/schemas/1.9.0", Scope: "https://opentelemetry.io/schemas/1.6.1"},
+			},
+			other: []SchemaURLs{
+				{},
+				{Resource: "https://opentelemetry.io/schemas/1.6.1"},
+				{Scope: "https://opentelemetry.io/schemas/1.9.0"},
+			},
+		},
+		{
+			name: "scope_regexp",
+			config: filterconfig.MatchProperties{
+				Config:          *createConfig(filterset.Regexp),
+				ScopeSchemaURLs: []string{"https://opentelemetry\\.io/schemas/1\\.9\\..*"},
+			},
+			matching: []SchemaURLs{
+				{Scope: "https://opentelemetry.io/schemas/1.9.0"},
+				{Scope: "https://opentelemetry.io/schemas/1.9.1", Resource: "https://opentelemetry.io/schemas/1.6.1"},
+			},
+			other: []SchemaURLs{
+				{},
+				{Scope: "https://opentelemetry.io/schemas/1.6.1"},
+				{Resource: "https://opentelemetry.io/schemas/1.9.0"},
+			},
+		},
+		{
+			name: "resource_and_scope",
+			config: filterconfig.MatchProperties{
+				Config:             *createConfig(filterset.Strict),
+				ResourceSchemaURLs: []string{"https://opentelemetry.io/schemas/1.9.0"},
+				ScopeSchemaURLs:    []string{"https://opentelemetry.io/schemas/1.6.1"},
+			},
+			matching: []SchemaURLs{
+				{Resource: "https://opentelemetry.io/schemas/1.9.0", Scope: "https://opentelemetry.io/schemas/1.6.1"},
+			},
+			other: []SchemaURLs{
+				{Resource: "https://opentelemetry.io/schemas/1.9.0"},
+				{Scope: "https://opentelemetry.io/schemas/1.6.1"},
+			},
+		},
+		{
+			name: "unset",
+			config: filterconfig.MatchProperties{
+				Config:     *createConfig(filterset.Strict),
+				Attributes: []filterconfig.Attribute{{Key: "keyString"}},
+			},
+			matching: []SchemaURLs{
+				{},
+				{Resource: "https://opentelemetry.io/schemas/1.9.0", Scope: "https://opentelemetry.io/schemas/1.6.1"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mp, err := NewMatcher(&tc.config)
+			require.NoError(t, err)
+
+			attrs := pcommon.NewMap()
+			attrs.PutStr("keyString", "arithmetic")
+			for _, schemaURLs := range tc.matching {
+				assert.True(t, mp.Match(attrs, resource("svcA"), pcommon.NewInstrumentationScope(), "", schemaURLs), "%v should match", schemaURLs)
+			}
+			for _, schemaURLs := range tc.other {
+				assert.False(t, mp.Match(attrs, resource("svcA"), pcommon.NewInstrumentationScope(), "", schemaURLs), "%v should not match", schemaURLs)
+			}
 		})
 	}
 }
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermatcher // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// matchFunction is the name of the function invoked by the statements built from the conditions.
+// OTTL only parses statements, so a condition is turned into a statement calling a function that
+// does nothing where the condition is met.
+const matchFunction = "match"
+
+// OTTLConditions matches data of the OTTL context K against OTTL boolean expressions.
+type OTTLConditions[K any] []*ottl.Statement[K]
+
+// NewOTTLConditions compiles the conditions with the parser returned by newParser for the OTTL
+// context of the matched data, e.g. ottltraces.NewParser for spans.
+func NewOTTLConditions[K any](conditions []string, newParser func(map[string]interface{}, component.TelemetrySettings) ottl.Parser[K]) (OTTLConditions[K], error) {
+	parser := newParser(conditionFunctions[K](), component.TelemetrySettings{Logger: zap.NewNop()})
+	statements := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		statements = append(statements, fmt.Sprintf("%s() where %s", matchFunction, condition))
+	}
+	parsed, err := parser.ParseStatements(statements)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTTL conditions: %w", err)
+	}
+	return parsed, nil
+}
+
+// Match returns whether at least one of the conditions is true for ctx. A condition that fails to
+// be evaluated, e.g. as it compares values of types that cannot be compared, isn't true.
+func (c OTTLConditions[K]) Match(ctx K) bool {
+	for _, statement := range c {
+		if _, matched, err := statement.Execute(ctx); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionFunctions returns the functions that can be invoked in the conditions.
+func conditionFunctions[K any]() map[string]interface{} {
+	return map[string]interface{}{
+		"Concat":  ottlfuncs.Concat[K],
+		"IsMatch": ottlfuncs.IsMatch[K],
+		"Int":     ottlfuncs.Int[K],
+		"SpanID":  ottlfuncs.SpanID[K],
+		"TraceID": ottlfuncs.TraceID[K],
+		matchFunction: func() (ottl.ExprFunc[K], error) {
+			return func(K) (interface{}, error) {
+				return nil, nil
+			}, nil
+		},
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermatcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
+)
+
+func Test_OTTLConditions(t *testing.T) {
+	testcases := []struct {
+		name       string
+		conditions []string
+		matches    bool
+	}{
+		{
+			name:       "comparison_true",
+			conditions: []string{`attributes["http.status_code"] >= 500`},
+			matches:    true,
+		},
+		{
+			name:       "comparison_false",
+			conditions: []string{`attributes["http.status_code"] < 500`},
+			matches:    false,
+		},
+		{
+			name:       "one_condition_true",
+			conditions: []string{`name == "other"`, `IsMatch(resource.attributes["service.name"], "svc.*") == true`},
+			matches:    true,
+		},
+		{
+			name:       "combined_condition",
+			conditions: []string{`name == "span" and attributes["http.status_code"] != 503`},
+			matches:    true,
+		},
+		{
+			name:       "missing_attribute",
+			conditions: []string{`attributes["missing"] == "value"`},
+			matches:    false,
+		},
+	}
+
+	span := ptrace.NewSpan()
+	span.SetName("span")
+	span.Attributes().PutInt("http.status_code", 502)
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "svcA")
+	ctx := ottltraces.NewTransformContext(span, pcommon.NewInstrumentationScope(), resource)
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions, err := NewOTTLConditions(tc.conditions, ottltraces.NewParser)
+			require.NoError(t, err)
+			assert.Equal(t, tc.matches, conditions.Match(ctx))
+		})
+	}
+}
+
+func Test_OTTLConditions_Invalid(t *testing.T) {
+	_, err := NewOTTLConditions([]string{`attributes["http.status_code"] >=`}, ottltraces.NewParser)
+	assert.ErrorContains(t, err, "error creating OTTL conditions")
+
+	_, err = NewOTTLConditions([]string{`unknown_path == 1`}, ottltraces.NewParser)
+	assert.ErrorContains(t, err, "error creating OTTL conditions")
+}
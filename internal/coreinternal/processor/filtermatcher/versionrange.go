@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermatcher // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+// versionOperators are ordered so that two-character operators are matched before their prefixes.
+var versionOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// versionRange matches the semantic versions satisfying all of its constraints, e.g. ">=1.2.0 <2.0.0".
+type versionRange []versionConstraint
+
+var _ filterset.FilterSet = versionRange(nil)
+
+type versionConstraint struct {
+	operator string
+	version  semanticVersion
+}
+
+// isVersionRange reports whether the version to match is a range rather than a filterset pattern,
+// which is the case when it starts with a comparison operator.
+func isVersionRange(s string) bool {
+	s = strings.TrimSpace(s)
+	return s != "" && strings.ContainsRune("<>=!", rune(s[0]))
+}
+
+// newVersionRange parses space separated constraints made of a comparison operator followed by a version.
+func newVersionRange(s string) (versionRange, error) {
+	var r versionRange
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		operator := versionOperator(fields[i])
+		if operator == "" {
+			return nil, fmt.Errorf("invalid version range %q: %q has no comparison operator", s, fields[i])
+		}
+		rawVersion := strings.TrimPrefix(fields[i], operator)
+		if rawVersion == "" && i+1 < len(fields) {
+			// the operator is separated from the version, e.g. ">= 1.2.0"
+			i++
+			rawVersion = fields[i]
+		}
+		version, ok := parseSemanticVersion(rawVersion)
+		if !ok {
+			return nil, fmt.Errorf("invalid version range %q: %q is not a semantic version", s, rawVersion)
+		}
+		r = append(r, versionConstraint{operator: operator, version: version})
+	}
+	return r, nil
+}
+
+func versionOperator(s string) string {
+	for _, operator := range versionOperators {
+		if strings.HasPrefix(s, operator) {
+			return operator
+		}
+	}
+	return ""
+}
+
+// Matches returns true if the given version is a semantic version satisfying all the constraints.
+func (r versionRange) Matches(version string) bool {
+	v, ok := parseSemanticVersion(version)
+	if !ok {
+		return false
+	}
+	for _, c := range r {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c versionConstraint) matches(v semanticVersion) bool {
+	cmp := v.compare(c.version)
+	switch c.operator {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp == 0
+	}
+}
+
+type semanticVersion struct {
+	core       [3]uint64
+	preRelease []string
+}
+
+// parseSemanticVersion parses versions such as "1.2.3", "v1.2" or "1.2.3-beta.1+build". Missing minor
+// and patch numbers are 0 and build metadata is ignored.
+func parseSemanticVersion(s string) (semanticVersion, bool) {
+	var v semanticVersion
+	s = strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.preRelease = strings.Split(s[i+1:], ".")
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > len(v.core) {
+		return semanticVersion{}, false
+	}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return semanticVersion{}, false
+		}
+		v.core[i] = n
+	}
+	return v, true
+}
+
+// compare returns -1, 0 or 1 depending on whether v precedes, equals or follows o, following the
+// precedence rules of semantic versioning.
+func (v semanticVersion) compare(o semanticVersion) int {
+	for i := range v.core {
+		if v.core[i] != o.core[i] {
+			if v.core[i] < o.core[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// a pre-release version precedes the associated normal version
+	switch {
+	case len(v.preRelease) == 0 && len(o.preRelease) == 0:
+		return 0
+	case len(v.preRelease) == 0:
+		return 1
+	case len(o.preRelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.preRelease) && i < len(o.preRelease); i++ {
+		if cmp := comparePreReleaseIdentifiers(v.preRelease[i], o.preRelease[i]); cmp != 0 {
+			return cmp
+		}
+	}
+	switch {
+	case len(v.preRelease) < len(o.preRelease):
+		return -1
+	case len(v.preRelease) > len(o.preRelease):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePreReleaseIdentifiers compares numeric identifiers numerically and others lexically,
+// numeric identifiers having a lower precedence.
+func comparePreReleaseIdentifiers(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		default:
+			return 0
+		}
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
@@ -41,6 +41,8 @@ var (
 type Config struct {
 	MatchType    MatchType      `mapstructure:"match_type"`
 	RegexpConfig *regexp.Config `mapstructure:"regexp"`
+	// CaseInsensitive makes the strict matches ignore the case. Regular expressions can use the (?i) flag instead.
+	CaseInsensitive bool `mapstructure:"case_insensitive"`
 }
 
 func NewUnrecognizedMatchTypeError(matchType MatchType) error {
@@ -53,7 +55,9 @@ func CreateFilterSet(filters []string, cfg *Config) (FilterSet, error) {
 	case Regexp:
 		return regexp.NewFilterSet(filters, cfg.RegexpConfig)
 	case Strict:
-		// Strict FilterSets do not have any extra configuration options, so call the constructor directly.
+		if cfg.CaseInsensitive {
+			return strict.NewCaseInsensitiveFilterSet(filters), nil
+		}
 		return strict.NewFilterSet(filters), nil
 	default:
 		return nil, NewUnrecognizedMatchTypeError(cfg.MatchType)
@@ -55,6 +55,10 @@ func TestConfig(t *testing.T) {
 		"strict/default": {
 			MatchType: Strict,
 		},
+		"strict/caseinsensitive": {
+			MatchType:       Strict,
+			CaseInsensitive: true,
+		},
 	}
 
 	for testName, actualCfg := range actualConfigs {
@@ -14,13 +14,17 @@
 
 package strict // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset/strict"
 
+import "strings"
+
 // FilterSet encapsulates a set of exact string match filters.
-// FilterSet is exported for convenience, but has unexported fields and should be constructed through NewFilterSet.
+// FilterSet is exported for convenience, but has unexported fields and should be constructed through NewFilterSet
+// or NewCaseInsensitiveFilterSet.
 //
 // regexpFilterSet satisfies the FilterSet interface from
 // "go.opentelemetry.io/collector/internal/processor/filterset"
 type FilterSet struct {
-	filters map[string]struct{}
+	filters         map[string]struct{}
+	caseInsensitive bool
 }
 
 // NewFilterSet constructs a FilterSet of exact string matches.
@@ -36,8 +40,25 @@ func NewFilterSet(filters []string) *FilterSet {
 	return fs
 }
 
+// NewCaseInsensitiveFilterSet constructs a FilterSet of exact string matches that ignore the case.
+func NewCaseInsensitiveFilterSet(filters []string) *FilterSet {
+	fs := &FilterSet{
+		filters:         make(map[string]struct{}, len(filters)),
+		caseInsensitive: true,
+	}
+
+	for _, f := range filters {
+		fs.filters[strings.ToLower(f)] = struct{}{}
+	}
+
+	return fs
+}
+
 // Matches returns true if the given string matches any of the FilterSet's filters.
 func (sfs *FilterSet) Matches(toMatch string) bool {
+	if sfs.caseInsensitive {
+		toMatch = strings.ToLower(toMatch)
+	}
 	_, ok := sfs.filters[toMatch]
 	return ok
 }
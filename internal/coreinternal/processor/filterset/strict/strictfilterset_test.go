@@ -79,3 +79,33 @@ func TestStrictMatches(t *testing.T) {
 		})
 	}
 }
+
+func TestCaseInsensitiveStrictMatches(t *testing.T) {
+	fs := NewCaseInsensitiveFilterSet([]string{"Content-Type", "exact_string_match"})
+	assert.NotNil(t, fs)
+
+	matches := []string{
+		"content-type",
+		"CONTENT-TYPE",
+		"Content-Type",
+		"Exact_String_Match",
+	}
+
+	for _, m := range matches {
+		t.Run(m, func(t *testing.T) {
+			assert.True(t, fs.Matches(m))
+		})
+	}
+
+	mismatches := []string{
+		"content_type",
+		"content-type-options",
+		"exact",
+	}
+
+	for _, m := range mismatches {
+		t.Run(m, func(t *testing.T) {
+			assert.False(t, fs.Matches(m))
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottltraces"
 )
 
 // Matcher is an interface that allows matching a span against a configuration
@@ -32,7 +33,7 @@ import (
 //
 //	calling processors will always have the same logic.
 type Matcher interface {
-	MatchSpan(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope) bool
+	MatchSpan(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope, schemaURLs filtermatcher.SchemaURLs) bool
 }
 
 // propertiesMatcher allows matching a span against various span properties.
@@ -47,6 +48,9 @@ type propertiesMatcher struct {
 
 	// Span kinds to compare to
 	kindFilters filterset.FilterSet
+
+	// OTTL conditions to evaluate
+	conditions filtermatcher.OTTLConditions[ottltraces.TransformContext]
 }
 
 // NewMatcher creates a span Matcher that matches based on the given MatchProperties.
@@ -88,11 +92,20 @@ func NewMatcher(mp *filterconfig.MatchProperties) (Matcher, error) {
 		}
 	}
 
+	var conditions filtermatcher.OTTLConditions[ottltraces.TransformContext]
+	if len(mp.OTTLConditions) > 0 {
+		conditions, err = filtermatcher.NewOTTLConditions(mp.OTTLConditions, ottltraces.NewParser)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &propertiesMatcher{
 		PropertiesMatcher: rm,
 		serviceFilters:    serviceFS,
 		nameFilters:       nameFS,
 		kindFilters:       kindFS,
+		conditions:        conditions,
 	}, nil
 }
 
@@ -102,17 +115,17 @@ func NewMatcher(mp *filterconfig.MatchProperties) (Matcher, error) {
 // The logic determining if a span should be processed is set
 // in the attribute configuration with the include and exclude settings.
 // Include properties are checked before exclude settings are checked.
-func SkipSpan(include Matcher, exclude Matcher, span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope) bool {
+func SkipSpan(include Matcher, exclude Matcher, span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope, schemaURLs filtermatcher.SchemaURLs) bool {
 	if include != nil {
 		// A false returned in this case means the span should not be processed.
-		if i := include.MatchSpan(span, resource, library); !i {
+		if i := include.MatchSpan(span, resource, library, schemaURLs); !i {
 			return true
 		}
 	}
 
 	if exclude != nil {
 		// A true returned in this case means the span should not be processed.
-		if e := exclude.MatchSpan(span, resource, library); e {
+		if e := exclude.MatchSpan(span, resource, library, schemaURLs); e {
 			return true
 		}
 	}
@@ -122,7 +135,7 @@ func SkipSpan(include Matcher, exclude Matcher, span ptrace.Span, resource pcomm
 
 // MatchSpan matches a span and service to a set of properties.
 // see filterconfig.MatchProperties for more details
-func (mp *propertiesMatcher) MatchSpan(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope) bool {
+func (mp *propertiesMatcher) MatchSpan(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope, schemaURLs filtermatcher.SchemaURLs) bool {
 	// If a set of properties was not in the mp, all spans are considered to match on that property
 	if mp.serviceFilters != nil {
 		// Check resource and spans for service.name
@@ -141,7 +154,11 @@ func (mp *propertiesMatcher) MatchSpan(span ptrace.Span, resource pcommon.Resour
 		return false
 	}
 
-	return mp.PropertiesMatcher.Match(span.Attributes(), resource, library)
+	if mp.conditions != nil && !mp.conditions.Match(ottltraces.NewTransformContext(span, library, resource)) {
+		return false
+	}
+
+	return mp.PropertiesMatcher.Match(span.Attributes(), resource, library, span.Name(), schemaURLs)
 }
 
 // serviceNameForResource gets the service name for a specified Resource.
@@ -24,6 +24,7 @@ import (
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
 )
@@ -155,6 +156,19 @@ func TestSpan_Matching_False(t *testing.T) {
 				Attributes: []filterconfig.Attribute{},
 			},
 		},
+		{
+			name: "name_doesnt_match",
+			properties: &filterconfig.MatchProperties{
+				Config: *createConfig(filterset.Strict),
+				Names:  []string{"span"},
+			},
+		},
+		{
+			name: "ottl_condition_doesnt_match",
+			properties: &filterconfig.MatchProperties{
+				OTTLConditions: []string{`kind == SPAN_KIND_PRODUCER`},
+			},
+		},
 		{
 			name: "span_kind_doesnt_match_regexp",
 			properties: &filterconfig.MatchProperties{
@@ -184,7 +198,7 @@ func TestSpan_Matching_False(t *testing.T) {
 			require.NoError(t, err)
 			assert.NotNil(t, matcher)
 
-			assert.False(t, matcher.MatchSpan(span, resource, library))
+			assert.False(t, matcher.MatchSpan(span, resource, library, filtermatcher.SchemaURLs{}))
 		})
 	}
 }
@@ -200,7 +214,7 @@ func TestSpan_MissingServiceName(t *testing.T) {
 	assert.NotNil(t, mp)
 
 	emptySpan := ptrace.NewSpan()
-	assert.False(t, mp.MatchSpan(emptySpan, pcommon.NewResource(), pcommon.NewInstrumentationScope()))
+	assert.False(t, mp.MatchSpan(emptySpan, pcommon.NewResource(), pcommon.NewInstrumentationScope(), filtermatcher.SchemaURLs{}))
 }
 
 func TestSpan_Matching_True(t *testing.T) {
@@ -245,6 +259,19 @@ func TestSpan_Matching_True(t *testing.T) {
 				Attributes: []filterconfig.Attribute{},
 			},
 		},
+		{
+			name: "name_match",
+			properties: &filterconfig.MatchProperties{
+				Config: *createConfig(filterset.Strict),
+				Names:  []string{"spanName"},
+			},
+		},
+		{
+			name: "ottl_condition_match",
+			properties: &filterconfig.MatchProperties{
+				OTTLConditions: []string{`attributes["keyInt"] > 100 and resource.attributes["service.name"] == "svcA"`},
+			},
+		},
 		{
 			name: "span_kind_match_strict",
 			properties: &filterconfig.MatchProperties{
@@ -289,7 +316,7 @@ func TestSpan_Matching_True(t *testing.T) {
 			require.NoError(t, err)
 			assert.NotNil(t, mp)
 
-			assert.True(t, mp.MatchSpan(span, resource, library))
+			assert.True(t, mp.MatchSpan(span, resource, library, filtermatcher.SchemaURLs{}))
 		})
 	}
 }
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Add(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		addend   float64
+		expected interface{}
+	}{
+		{
+			name:     "int64 with whole addend",
+			value:    int64(10),
+			addend:   5,
+			expected: int64(15),
+		},
+		{
+			name:     "int64 with negative whole addend",
+			value:    int64(10),
+			addend:   -15,
+			expected: int64(-5),
+		},
+		{
+			name:     "int64 with fractional addend",
+			value:    int64(10),
+			addend:   0.5,
+			expected: float64(10.5),
+		},
+		{
+			name:     "int64 with addend out of the int64 range",
+			value:    int64(2),
+			addend:   1e19,
+			expected: float64(1e19) + 2,
+		},
+		{
+			name:     "int64 overflowing with whole addend",
+			value:    int64(math.MaxInt64),
+			addend:   1,
+			expected: float64(math.MaxInt64) + 1,
+		},
+		{
+			name:     "int64 underflowing with negative whole addend",
+			value:    int64(math.MinInt64),
+			addend:   -1,
+			expected: float64(math.MinInt64) - 1,
+		},
+		{
+			name:     "float64 with whole addend",
+			value:    float64(1.5),
+			addend:   2,
+			expected: float64(3.5),
+		},
+		{
+			name:     "float64 with fractional addend",
+			value:    float64(1.25),
+			addend:   0.5,
+			expected: float64(1.75),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Add[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.addend)
+			assert.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Add_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{
+			name:  "string",
+			value: "2",
+		},
+		{
+			name:  "bool",
+			value: true,
+		},
+		{
+			name:  "nil",
+			value: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Add[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, 2)
+			assert.NoError(t, err)
+			_, err = exprFunc(nil)
+			assert.Error(t, err)
+		})
+	}
+}
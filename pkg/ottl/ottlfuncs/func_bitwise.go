@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func BitAnd[K any](a ottl.Getter[K], b ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return bitwise("BitAnd", a, b, func(x, y int64) int64 { return x & y }), nil
+}
+
+func BitOr[K any](a ottl.Getter[K], b ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return bitwise("BitOr", a, b, func(x, y int64) int64 { return x | y }), nil
+}
+
+func BitXor[K any](a ottl.Getter[K], b ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return bitwise("BitXor", a, b, func(x, y int64) int64 { return x ^ y }), nil
+}
+
+// bitwise returns an ExprFunc applying op to the integers returned by the getters.
+func bitwise[K any](funcName string, a ottl.Getter[K], b ottl.Getter[K], op func(x, y int64) int64) ottl.ExprFunc[K] {
+	return func(ctx K) (interface{}, error) {
+		x, err := getBitwiseOperand(ctx, funcName, a)
+		if err != nil {
+			return nil, err
+		}
+		y, err := getBitwiseOperand(ctx, funcName, b)
+		if err != nil {
+			return nil, err
+		}
+		return op(x, y), nil
+	}
+}
+
+func getBitwiseOperand[K any](ctx K, funcName string, getter ottl.Getter[K]) (int64, error) {
+	val, err := getter.Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	i, ok := val.(int64)
+	if !ok {
+		return 0, fmt.Errorf("%s requires integer arguments, but got %T", funcName, val)
+	}
+	return i, nil
+}
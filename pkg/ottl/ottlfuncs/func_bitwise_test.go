@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_bitwise(t *testing.T) {
+	tests := []struct {
+		name     string
+		function func(ottl.Getter[interface{}], ottl.Getter[interface{}]) (ottl.ExprFunc[interface{}], error)
+		a        int64
+		b        int64
+		expected int64
+	}{
+		{
+			name:     "and",
+			function: BitAnd[interface{}],
+			a:        0b1100,
+			b:        0b1010,
+			expected: 0b1000,
+		},
+		{
+			name:     "and mask",
+			function: BitAnd[interface{}],
+			a:        0x1234,
+			b:        0xff,
+			expected: 0x34,
+		},
+		{
+			name:     "and negative",
+			function: BitAnd[interface{}],
+			a:        -1,
+			b:        0b101,
+			expected: 0b101,
+		},
+		{
+			name:     "or",
+			function: BitOr[interface{}],
+			a:        0b1100,
+			b:        0b1010,
+			expected: 0b1110,
+		},
+		{
+			name:     "or zero",
+			function: BitOr[interface{}],
+			a:        0,
+			b:        0x80,
+			expected: 0x80,
+		},
+		{
+			name:     "or sign bit",
+			function: BitOr[interface{}],
+			a:        -1 << 63,
+			b:        1,
+			expected: -1<<63 | 1,
+		},
+		{
+			name:     "xor",
+			function: BitXor[interface{}],
+			a:        0b1100,
+			b:        0b1010,
+			expected: 0b0110,
+		},
+		{
+			name:     "xor same",
+			function: BitXor[interface{}],
+			a:        0xdead,
+			b:        0xdead,
+			expected: 0,
+		},
+		{
+			name:     "xor all ones",
+			function: BitXor[interface{}],
+			a:        0b1010,
+			b:        -1,
+			expected: ^int64(0b1010),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := tt.function(
+				&ottl.StandardGetSetter[interface{}]{
+					Getter: func(interface{}) (interface{}, error) {
+						return tt.a, nil
+					},
+				},
+				&ottl.StandardGetSetter[interface{}]{
+					Getter: func(interface{}) (interface{}, error) {
+						return tt.b, nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_bitwise_error(t *testing.T) {
+	tests := []struct {
+		name     string
+		function func(ottl.Getter[interface{}], ottl.Getter[interface{}]) (ottl.ExprFunc[interface{}], error)
+		a        interface{}
+		b        interface{}
+		err      string
+	}{
+		{
+			name:     "double",
+			function: BitAnd[interface{}],
+			a:        1.5,
+			b:        int64(1),
+			err:      "BitAnd requires integer arguments, but got float64",
+		},
+		{
+			name:     "string",
+			function: BitOr[interface{}],
+			a:        int64(1),
+			b:        "1",
+			err:      "BitOr requires integer arguments, but got string",
+		},
+		{
+			name:     "nil",
+			function: BitXor[interface{}],
+			a:        nil,
+			b:        int64(1),
+			err:      "BitXor requires integer arguments, but got <nil>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := tt.function(
+				&ottl.StandardGetSetter[interface{}]{
+					Getter: func(interface{}) (interface{}, error) {
+						return tt.a, nil
+					},
+				},
+				&ottl.StandardGetSetter[interface{}]{
+					Getter: func(interface{}) (interface{}, error) {
+						return tt.b, nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			_, err = exprFunc(nil)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Ceil(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected float64
+	}{
+		{
+			name:     "positive float64",
+			value:    float64(2.3),
+			expected: float64(3),
+		},
+		{
+			name:     "negative float64",
+			value:    float64(-2.7),
+			expected: float64(-2),
+		},
+		{
+			name:     "whole float64",
+			value:    float64(4),
+			expected: float64(4),
+		},
+		{
+			name:     "positive int64",
+			value:    int64(5),
+			expected: float64(5),
+		},
+		{
+			name:     "negative int64",
+			value:    int64(-5),
+			expected: float64(-5),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Ceil[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			assert.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Ceil_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{
+			name:  "string",
+			value: "2.3",
+		},
+		{
+			name:  "nil",
+			value: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Ceil[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			})
+			assert.NoError(t, err)
+			_, err = exprFunc(nil)
+			assert.Error(t, err)
+		})
+	}
+}
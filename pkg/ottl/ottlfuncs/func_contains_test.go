@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		substr   string
+		expected bool
+	}{
+		{
+			name:     "substring present",
+			value:    "hello world",
+			substr:   "o w",
+			expected: true,
+		},
+		{
+			name:     "substring absent",
+			value:    "hello world",
+			substr:   "goodbye",
+			expected: false,
+		},
+		{
+			name:     "case sensitive",
+			value:    "hello world",
+			substr:   "World",
+			expected: false,
+		},
+		{
+			name:     "empty substring",
+			value:    "hello world",
+			substr:   "",
+			expected: true,
+		},
+		{
+			name:     "empty target and empty substring",
+			value:    "",
+			substr:   "",
+			expected: true,
+		},
+		{
+			name:     "byte slice",
+			value:    []byte("hello world"),
+			substr:   "world",
+			expected: true,
+		},
+		{
+			name:     "int",
+			value:    int64(404),
+			substr:   "40",
+			expected: true,
+		},
+		{
+			name:     "double",
+			value:    1.5,
+			substr:   ".5",
+			expected: true,
+		},
+		{
+			name:     "bool",
+			value:    true,
+			substr:   "ru",
+			expected: true,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			substr:   "",
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Contains[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.substr)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
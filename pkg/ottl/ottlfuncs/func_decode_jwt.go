@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const bearerPrefix = "Bearer "
+
+func DecodeJWT[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		token, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("DecodeJWT requires a string, got %T", val)
+		}
+		claims, err := decodeJWTPayload(strings.TrimPrefix(token, bearerPrefix))
+		if err != nil {
+			return nil, err
+		}
+		return claims, nil
+	}, nil
+}
+
+// decodeJWTPayload returns the claims of the payload of a JWT in the JWS compact serialization,
+// without verifying its signature.
+func decodeJWTPayload(token string) (pcommon.Map, error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return pcommon.Map{}, fmt.Errorf("invalid JWT: expected 3 segments, got %d", len(segments))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segments[1], "="))
+	if err != nil {
+		return pcommon.Map{}, fmt.Errorf("invalid JWT payload: %w", err)
+	}
+
+	// numbers are decoded as json.Number so that integer claims, such as "exp", stay integers
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var claims map[string]interface{}
+	if err = decoder.Decode(&claims); err != nil {
+		return pcommon.Map{}, fmt.Errorf("invalid JWT payload: %w", err)
+	}
+	if claims == nil {
+		return pcommon.Map{}, fmt.Errorf("invalid JWT payload: the claims must be a JSON object")
+	}
+
+	convertJSONNumbers(claims)
+	result := pcommon.NewMap()
+	result.FromRaw(claims)
+	return result, nil
+}
+
+// convertJSONNumbers replaces the json.Number values of a decoded JSON value with int64 values,
+// or float64 values for numbers that aren't integers.
+func convertJSONNumbers(v interface{}) interface{} {
+	switch value := v.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	case map[string]interface{}:
+		for k, item := range value {
+			value[k] = convertJSONNumbers(item)
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = convertJSONNumbers(item)
+		}
+		return value
+	default:
+		return v
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// sampleJWT is signed with HS256 and has the payload
+// {"sub":"1234567890","name":"John Doe","admin":true,"iat":1516239022,"scope":["read","write"],"ratio":0.5,"org":{"id":42}}
+const sampleJWT = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+	"eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIiwiYWRtaW4iOnRydWUsImlhdCI6MTUxNjIzOTAyMiwic2NvcGUiOlsicmVhZCIsIndyaXRlIl0sInJhdGlvIjowLjUsIm9yZyI6eyJpZCI6NDJ9fQ." +
+	"SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c"
+
+func Test_DecodeJWT(t *testing.T) {
+	expectedClaims := map[string]interface{}{
+		"sub":   "1234567890",
+		"name":  "John Doe",
+		"admin": true,
+		"iat":   int64(1516239022),
+		"scope": []interface{}{"read", "write"},
+		"ratio": 0.5,
+		"org": map[string]interface{}{
+			"id": int64(42),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]interface{}
+	}{
+		{
+			name:     "signed token",
+			target:   sampleJWT,
+			expected: expectedClaims,
+		},
+		{
+			name:     "bearer token",
+			target:   "Bearer " + sampleJWT,
+			expected: expectedClaims,
+		},
+		{
+			// {"alg":"none"} and {"sub":"anonymous"}
+			name:   "unsecured token",
+			target: "eyJhbGciOiJub25lIn0.eyJzdWIiOiJhbm9ueW1vdXMifQ.",
+			expected: map[string]interface{}{
+				"sub": "anonymous",
+			},
+		},
+		{
+			// {"alg":"none"} and {"sub":"a"}, with padding
+			name:   "padded payload",
+			target: "eyJhbGciOiJub25lIn0.eyJzdWIiOiJhIn0=.",
+			expected: map[string]interface{}{
+				"sub": "a",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := DecodeJWT[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			require.NoError(t, err)
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_DecodeJWT_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target interface{}
+	}{
+		{
+			name:   "not a token",
+			target: "not a token",
+		},
+		{
+			name:   "missing signature segment",
+			target: "eyJhbGciOiJub25lIn0.eyJzdWIiOiJhbm9ueW1vdXMifQ",
+		},
+		{
+			name:   "too many segments",
+			target: "a.b.c.d.e",
+		},
+		{
+			name:   "invalid base64",
+			target: "eyJhbGciOiJub25lIn0.!!!.",
+		},
+		{
+			// "not json"
+			name:   "payload is not json",
+			target: "eyJhbGciOiJub25lIn0.bm90IGpzb24.",
+		},
+		{
+			// ["a"]
+			name:   "payload is not an object",
+			target: "eyJhbGciOiJub25lIn0.WyJhIl0.",
+		},
+		{
+			name:   "not a string",
+			target: int64(1),
+		},
+		{
+			name:   "nil",
+			target: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := DecodeJWT[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func DistinctCount[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slice, ok := val.(pcommon.Slice)
+		if !ok {
+			return nil, fmt.Errorf("DistinctCount requires a slice, got %T", val)
+		}
+
+		// the type is part of the key so that e.g. the string "1" and the int 1 are distinct
+		type element struct {
+			valueType pcommon.ValueType
+			value     string
+		}
+		distinct := make(map[element]struct{}, slice.Len())
+		for i := 0; i < slice.Len(); i++ {
+			v := slice.At(i)
+			switch v.Type() {
+			case pcommon.ValueTypeMap, pcommon.ValueTypeSlice:
+				return nil, fmt.Errorf("DistinctCount requires scalar elements, got %s at index %d", v.Type(), i)
+			}
+			distinct[element{valueType: v.Type(), value: v.AsString()}] = struct{}{}
+		}
+		return int64(len(distinct)), nil
+	}, nil
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_DistinctCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []interface{}
+		expected int64
+	}{
+		{
+			name:     "empty",
+			values:   []interface{}{},
+			expected: 0,
+		},
+		{
+			name:     "all unique",
+			values:   []interface{}{"a", "b", "c"},
+			expected: 3,
+		},
+		{
+			name:     "duplicates",
+			values:   []interface{}{"a", "b", "a", "c", "b", "a"},
+			expected: 3,
+		},
+		{
+			name:     "all duplicates",
+			values:   []interface{}{int64(7), int64(7), int64(7)},
+			expected: 1,
+		},
+		{
+			name:     "same representation with different types",
+			values:   []interface{}{"1", int64(1), 1.5, "1.5", true, "true"},
+			expected: 6,
+		},
+		{
+			name:     "mixed duplicates",
+			values:   []interface{}{"a", int64(1), 2.5, false, "a", int64(1), 2.5, false, nil, nil},
+			expected: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slice := pcommon.NewSlice()
+			slice.FromRaw(tt.values)
+			exprFunc, err := DistinctCount[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return slice, nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_DistinctCount_error(t *testing.T) {
+	nested := pcommon.NewSlice()
+	nested.AppendEmpty().SetStr("a")
+	nested.AppendEmpty().SetEmptyMap().PutStr("b", "c")
+
+	tests := []struct {
+		name   string
+		target interface{}
+	}{
+		{
+			name:   "not a slice",
+			target: "a,b,c",
+		},
+		{
+			name:   "nil",
+			target: nil,
+		},
+		{
+			name:   "non-scalar element",
+			target: nested,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := DistinctCount[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			})
+			require.NoError(t, err)
+			_, err = exprFunc(nil)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func EqualsIgnoreCase[K any](a ottl.Getter[K], b ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		aStr, ok, err := getStringForEqualsIgnoreCase(ctx, a)
+		if err != nil || !ok {
+			return false, err
+		}
+		bStr, ok, err := getStringForEqualsIgnoreCase(ctx, b)
+		if err != nil || !ok {
+			return false, err
+		}
+		return strings.EqualFold(aStr, bStr), nil
+	}, nil
+}
+
+func getStringForEqualsIgnoreCase[K any](ctx K, target ottl.Getter[K]) (string, bool, error) {
+	val, err := target.Get(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	switch v := val.(type) {
+	case string:
+		return v, true, nil
+	case []byte:
+		return string(v), true, nil
+	case int64, float64, bool:
+		return fmt.Sprint(v), true, nil
+	default:
+		return "", false, nil
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_EqualsIgnoreCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        interface{}
+		b        interface{}
+		expected bool
+	}{
+		{
+			name:     "same case",
+			a:        "GET",
+			b:        "GET",
+			expected: true,
+		},
+		{
+			name:     "different case",
+			a:        "Get",
+			b:        "gET",
+			expected: true,
+		},
+		{
+			name:     "unicode case folding",
+			a:        "ΣΊΣΥΦΟΣ",
+			b:        "σίσυφος",
+			expected: true,
+		},
+		{
+			name:     "different strings",
+			a:        "GET",
+			b:        "POST",
+			expected: false,
+		},
+		{
+			name:     "bytes",
+			a:        []byte("Error"),
+			b:        "ERROR",
+			expected: true,
+		},
+		{
+			name:     "bool and string",
+			a:        true,
+			b:        "TRUE",
+			expected: true,
+		},
+		{
+			name:     "int and string",
+			a:        int64(200),
+			b:        "200",
+			expected: true,
+		},
+		{
+			name:     "float and int",
+			a:        float64(1.5),
+			b:        int64(1),
+			expected: false,
+		},
+		{
+			name:     "nil",
+			a:        nil,
+			b:        "",
+			expected: false,
+		},
+		{
+			name:     "unsupported type",
+			a:        map[string]interface{}{},
+			b:        "map[]",
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) (interface{}, error) {
+					return tt.a, nil
+				},
+			}
+			b := &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) (interface{}, error) {
+					return tt.b, nil
+				},
+			}
+			exprFunc, err := EqualsIgnoreCase[interface{}](a, b)
+			require.NoError(t, err)
+
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_EqualsIgnoreCase_error(t *testing.T) {
+	a := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) (interface{}, error) {
+			return nil, errors.New("failed")
+		},
+	}
+	b := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) (interface{}, error) {
+			return "value", nil
+		},
+	}
+	exprFunc, err := EqualsIgnoreCase[interface{}](a, b)
+	require.NoError(t, err)
+
+	_, err = exprFunc(nil)
+	assert.Error(t, err)
+}
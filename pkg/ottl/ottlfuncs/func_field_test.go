@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Field(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    interface{}
+		delimiter string
+		index     int64
+		expected  interface{}
+	}{
+		{
+			name:      "first field",
+			target:    "GET /api/v1/users 200",
+			delimiter: " ",
+			index:     0,
+			expected:  "GET",
+		},
+		{
+			name:      "middle field",
+			target:    "GET /api/v1/users 200",
+			delimiter: " ",
+			index:     1,
+			expected:  "/api/v1/users",
+		},
+		{
+			name:      "multi character delimiter",
+			target:    "a::b::c",
+			delimiter: "::",
+			index:     2,
+			expected:  "c",
+		},
+		{
+			name:      "empty field",
+			target:    "a,,c",
+			delimiter: ",",
+			index:     1,
+			expected:  "",
+		},
+		{
+			name:      "delimiter not found",
+			target:    "abc",
+			delimiter: ",",
+			index:     0,
+			expected:  "abc",
+		},
+		{
+			name:      "last field from the end",
+			target:    "a,b,c",
+			delimiter: ",",
+			index:     -1,
+			expected:  "c",
+		},
+		{
+			name:      "first field from the end",
+			target:    "a,b,c",
+			delimiter: ",",
+			index:     -3,
+			expected:  "a",
+		},
+		{
+			name:      "index out of range",
+			target:    "a,b,c",
+			delimiter: ",",
+			index:     3,
+			expected:  nil,
+		},
+		{
+			name:      "negative index out of range",
+			target:    "a,b,c",
+			delimiter: ",",
+			index:     -4,
+			expected:  nil,
+		},
+		{
+			name:      "non-string target",
+			target:    int64(1),
+			delimiter: ",",
+			index:     0,
+			expected:  nil,
+		},
+		{
+			name:      "nil target",
+			target:    nil,
+			delimiter: ",",
+			index:     0,
+			expected:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Field[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			}, tt.delimiter, tt.index)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
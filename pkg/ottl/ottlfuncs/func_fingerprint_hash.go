@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func FingerprintHash[K any](vals []ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		h := fnv.New64a()
+		for _, rv := range vals {
+			val, err := rv.Get(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if !writeFingerprint(h, val) {
+				return nil, fmt.Errorf("FingerprintHash does not support values of type %T", val)
+			}
+		}
+		return int64(h.Sum64()), nil
+	}, nil
+}
+
+// writeFingerprint writes val to h, returning false if its type isn't supported.
+// Every value is prefixed with its type and terminated by a separator
+// so that e.g. ("ab", "c") and ("a", "bc") produce different fingerprints.
+func writeFingerprint(h hash.Hash64, val interface{}) bool {
+	var s string
+	switch v := val.(type) {
+	case string:
+		s = "s" + v
+	case []byte:
+		s = "x" + fmt.Sprintf("%x", v)
+	case int64:
+		s = "i" + strconv.FormatInt(v, 10)
+	case float64:
+		s = "f" + strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		s = "b" + strconv.FormatBool(v)
+	case nil:
+		s = "n"
+	default:
+		return false
+	}
+	_, _ = h.Write([]byte(s))
+	_, _ = h.Write([]byte{0})
+	return true
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func fingerprint(t *testing.T, values ...interface{}) interface{} {
+	getters := make([]ottl.Getter[interface{}], len(values))
+	for i, v := range values {
+		v := v
+		getters[i] = &ottl.StandardGetSetter[interface{}]{
+			Getter: func(interface{}) (interface{}, error) {
+				return v, nil
+			},
+		}
+	}
+	exprFunc, err := FingerprintHash(getters)
+	require.NoError(t, err)
+	result, err := exprFunc(nil)
+	require.NoError(t, err)
+	return result
+}
+
+// The expected values are fixed so that a change in the fingerprint of the same
+// input, which would change sampling decisions across collector versions, is caught.
+func Test_FingerprintHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []interface{}
+		expected int64
+	}{
+		{
+			name:     "no values",
+			values:   nil,
+			expected: -3750763034362895579,
+		},
+		{
+			name:     "strings",
+			values:   []interface{}{"checkout", "GET /cart"},
+			expected: 9613182690992706,
+		},
+		{
+			name:     "mixed types",
+			values:   []interface{}{int64(42), 3.5, true, nil, []byte{0x01, 0x02}},
+			expected: -1588552856558663513,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fingerprint(t, tt.values...))
+			assert.Equal(t, tt.expected, fingerprint(t, tt.values...))
+		})
+	}
+}
+
+func Test_FingerprintHash_sensitivity(t *testing.T) {
+	base := fingerprint(t, "checkout", "GET /cart")
+	tests := []struct {
+		name   string
+		values []interface{}
+	}{
+		{
+			name:   "different value",
+			values: []interface{}{"checkout", "GET /carts"},
+		},
+		{
+			name:   "different order",
+			values: []interface{}{"GET /cart", "checkout"},
+		},
+		{
+			name:   "different boundary",
+			values: []interface{}{"checkoutGET", " /cart"},
+		},
+		{
+			name:   "additional value",
+			values: []interface{}{"checkout", "GET /cart", nil},
+		},
+		{
+			name:   "single value",
+			values: []interface{}{"checkoutGET /cart"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotEqual(t, base, fingerprint(t, tt.values...))
+		})
+	}
+
+	assert.NotEqual(t, fingerprint(t, int64(1)), fingerprint(t, "1"))
+	assert.NotEqual(t, fingerprint(t, int64(1)), fingerprint(t, float64(1)))
+}
+
+func Test_FingerprintHash_error(t *testing.T) {
+	exprFunc, err := FingerprintHash([]ottl.Getter[interface{}]{
+		&ottl.StandardGetSetter[interface{}]{
+			Getter: func(interface{}) (interface{}, error) {
+				return map[string]interface{}{"foo": "bar"}, nil
+			},
+		},
+	})
+	require.NoError(t, err)
+	_, err = exprFunc(nil)
+	assert.Error(t, err)
+}
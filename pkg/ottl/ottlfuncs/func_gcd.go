@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func GCD[K any](a ottl.Getter[K], b ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		x, err := getGCDOperand(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		y, err := getGCDOperand(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		for y != 0 {
+			x, y = y, x%y
+		}
+		if x > math.MaxInt64 {
+			return nil, fmt.Errorf("GCD result %d overflows int64", x)
+		}
+		return int64(x), nil
+	}, nil
+}
+
+// getGCDOperand returns the absolute value of the integer returned by the getter, which can't overflow as a uint64.
+func getGCDOperand[K any](ctx K, getter ottl.Getter[K]) (uint64, error) {
+	val, err := getter.Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	i, ok := val.(int64)
+	if !ok {
+		return 0, fmt.Errorf("GCD requires integer arguments, but got %T", val)
+	}
+	if i < 0 {
+		return uint64(-(i + 1)) + 1, nil
+	}
+	return uint64(i), nil
+}
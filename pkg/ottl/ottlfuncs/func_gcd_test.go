@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_GCD(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        int64
+		b        int64
+		expected int64
+	}{
+		{
+			name:     "coprime",
+			a:        35,
+			b:        64,
+			expected: 1,
+		},
+		{
+			name:     "common factor",
+			a:        84,
+			b:        36,
+			expected: 12,
+		},
+		{
+			name:     "multiple",
+			a:        7,
+			b:        42,
+			expected: 7,
+		},
+		{
+			name:     "negative",
+			a:        -84,
+			b:        36,
+			expected: 12,
+		},
+		{
+			name:     "zero",
+			a:        0,
+			b:        15,
+			expected: 15,
+		},
+		{
+			name:     "both zero",
+			a:        0,
+			b:        0,
+			expected: 0,
+		},
+		{
+			name:     "min int64",
+			a:        math.MinInt64,
+			b:        6,
+			expected: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := GCD[interface{}](gcdGetter(tt.a), gcdGetter(tt.b))
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_GCD_error(t *testing.T) {
+	tests := []struct {
+		name string
+		a    interface{}
+		b    interface{}
+	}{
+		{
+			name: "float",
+			a:    int64(4),
+			b:    2.0,
+		},
+		{
+			name: "string",
+			a:    "4",
+			b:    int64(2),
+		},
+		{
+			name: "nil",
+			a:    nil,
+			b:    int64(2),
+		},
+		{
+			name: "overflow",
+			a:    int64(math.MinInt64),
+			b:    int64(0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := GCD[interface{}](gcdGetter(tt.a), gcdGetter(tt.b))
+			require.NoError(t, err)
+			_, err = exprFunc(nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func gcdGetter(value interface{}) ottl.Getter[interface{}] {
+	return &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) (interface{}, error) {
+			return value, nil
+		},
+	}
+}
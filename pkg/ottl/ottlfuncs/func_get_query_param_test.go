@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_GetQueryParam(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		param    string
+		expected interface{}
+	}{
+		{
+			name:     "present",
+			target:   "https://example.com/search?q=otel&page=2",
+			param:    "page",
+			expected: "2",
+		},
+		{
+			name:     "absent",
+			target:   "https://example.com/search?q=otel",
+			param:    "page",
+			expected: nil,
+		},
+		{
+			name:     "repeated",
+			target:   "https://example.com/search?tag=a&tag=b",
+			param:    "tag",
+			expected: "a",
+		},
+		{
+			name:     "empty value",
+			target:   "https://example.com/search?q=&page=2",
+			param:    "q",
+			expected: "",
+		},
+		{
+			name:     "encoded value",
+			target:   "/search?q=hello%20world%26more",
+			param:    "q",
+			expected: "hello world&more",
+		},
+		{
+			name:     "without query",
+			target:   "https://example.com/search",
+			param:    "q",
+			expected: nil,
+		},
+		{
+			name:     "malformed parameter",
+			target:   "/search?bad=%zz&q=otel",
+			param:    "q",
+			expected: "otel",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := GetQueryParam[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			}, tt.param)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_GetQueryParam_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target interface{}
+	}{
+		{
+			name:   "invalid URL",
+			target: "http://[::1",
+		},
+		{
+			name:   "not a string",
+			target: int64(1),
+		},
+		{
+			name:   "nil",
+			target: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := GetQueryParam[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			}, "q")
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
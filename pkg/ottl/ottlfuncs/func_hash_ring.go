@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func HashRing[K any](target ottl.Getter[K], buckets int64) (ottl.ExprFunc[K], error) {
+	if buckets <= 0 {
+		return nil, fmt.Errorf("the number of buckets supplied to HashRing must be positive, got %d", buckets)
+	}
+
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		h := fnv.New64a()
+		if !writeFingerprint(h, val) {
+			return nil, fmt.Errorf("HashRing does not support values of type %T", val)
+		}
+		return jumpHash(h.Sum64(), buckets), nil
+	}, nil
+}
+
+// jumpHash maps key to a bucket in [0, buckets) with the jump consistent hash of Lamping and Veach,
+// so that only about 1/buckets of the keys move to another bucket when a bucket is added.
+func jumpHash(key uint64, buckets int64) int64 {
+	var b, j int64 = -1, 0
+	for j < buckets {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return b
+}
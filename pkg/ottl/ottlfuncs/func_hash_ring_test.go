@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func hashRing(t *testing.T, value interface{}, buckets int64) int64 {
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(interface{}) (interface{}, error) {
+			return value, nil
+		},
+	}
+	exprFunc, err := HashRing[interface{}](target, buckets)
+	require.NoError(t, err)
+	result, err := exprFunc(nil)
+	require.NoError(t, err)
+	return result.(int64)
+}
+
+// The expected buckets are fixed so that a change in the bucket of the same
+// input, which would reshard the records across collector versions, is caught.
+func Test_HashRing(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		buckets  int64
+		expected int64
+	}{
+		{
+			name:     "string",
+			value:    "checkout",
+			buckets:  8,
+			expected: 0,
+		},
+		{
+			name:     "int",
+			value:    int64(42),
+			buckets:  8,
+			expected: 1,
+		},
+		{
+			name:     "bytes",
+			value:    []byte{0x01, 0x02},
+			buckets:  100,
+			expected: 86,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			buckets:  8,
+			expected: 0,
+		},
+		{
+			name:     "single bucket",
+			value:    "checkout",
+			buckets:  1,
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hashRing(t, tt.value, tt.buckets))
+			assert.Equal(t, tt.expected, hashRing(t, tt.value, tt.buckets))
+		})
+	}
+}
+
+func Test_HashRing_bounds(t *testing.T) {
+	counts := make([]int, 10)
+	for i := 0; i < 10000; i++ {
+		bucket := hashRing(t, fmt.Sprintf("key-%d", i), int64(len(counts)))
+		require.GreaterOrEqual(t, bucket, int64(0))
+		require.Less(t, bucket, int64(len(counts)))
+		counts[bucket]++
+	}
+	// the keys are spread evenly across the buckets
+	for bucket, count := range counts {
+		assert.InDelta(t, 1000, count, 150, "bucket %d", bucket)
+	}
+}
+
+func Test_HashRing_consistency(t *testing.T) {
+	moved := 0
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		before, after := hashRing(t, key, 10), hashRing(t, key, 11)
+		if before != after {
+			// a key only ever moves to the added bucket
+			require.Equal(t, int64(10), after)
+			moved++
+		}
+	}
+	assert.InDelta(t, 10000/11, moved, 150)
+}
+
+func Test_HashRing_invalid_buckets(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{}
+	for _, buckets := range []int64{0, -1} {
+		_, err := HashRing[interface{}](target, buckets)
+		assert.EqualError(t, err, fmt.Sprintf("the number of buckets supplied to HashRing must be positive, got %d", buckets))
+	}
+}
+
+func Test_HashRing_unsupported_type(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(interface{}) (interface{}, error) {
+			return map[string]interface{}{}, nil
+		},
+	}
+	exprFunc, err := HashRing[interface{}](target, 8)
+	require.NoError(t, err)
+	_, err = exprFunc(nil)
+	assert.EqualError(t, err, "HashRing does not support values of type map[string]interface {}")
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_indexOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		substr   string
+		expected interface{}
+	}{
+		{
+			name:     "present",
+			value:    "GET /api/v1/users",
+			substr:   "/api",
+			expected: int64(4),
+		},
+		{
+			name:     "first occurrence",
+			value:    "a,b,c",
+			substr:   ",",
+			expected: int64(1),
+		},
+		{
+			name:     "absent",
+			value:    "GET /api/v1/users",
+			substr:   "POST",
+			expected: int64(-1),
+		},
+		{
+			name:     "empty substring",
+			value:    "GET /api/v1/users",
+			substr:   "",
+			expected: int64(0),
+		},
+		{
+			name:     "empty target",
+			value:    "",
+			substr:   "a",
+			expected: int64(-1),
+		},
+		{
+			name:     "byte index",
+			value:    "héllo",
+			substr:   "l",
+			expected: int64(3),
+		},
+		{
+			name:     "not a string",
+			value:    int64(1),
+			substr:   "1",
+			expected: nil,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			substr:   "a",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}
+			exprFunc, err := IndexOf[interface{}](target, tt.substr)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_IsDivisibleBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   interface{}
+		divisor  int64
+		expected bool
+	}{
+		{
+			name:     "divisible",
+			target:   int64(30),
+			divisor:  10,
+			expected: true,
+		},
+		{
+			name:     "not divisible",
+			target:   int64(31),
+			divisor:  10,
+			expected: false,
+		},
+		{
+			name:     "zero",
+			target:   int64(0),
+			divisor:  7,
+			expected: true,
+		},
+		{
+			name:     "negative target",
+			target:   int64(-20),
+			divisor:  10,
+			expected: true,
+		},
+		{
+			name:     "negative target not divisible",
+			target:   int64(-21),
+			divisor:  10,
+			expected: false,
+		},
+		{
+			name:     "negative divisor",
+			target:   int64(20),
+			divisor:  -5,
+			expected: true,
+		},
+		{
+			name:     "min int64 by minus one",
+			target:   int64(math.MinInt64),
+			divisor:  -1,
+			expected: true,
+		},
+		{
+			name:     "float target",
+			target:   30.0,
+			divisor:  10,
+			expected: false,
+		},
+		{
+			name:     "string target",
+			target:   "30",
+			divisor:  10,
+			expected: false,
+		},
+		{
+			name:     "nil target",
+			target:   nil,
+			divisor:  10,
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := IsDivisibleBy[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			}, tt.divisor)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_IsDivisibleBy_validation(t *testing.T) {
+	_, err := IsDivisibleBy[interface{}](&ottl.StandardGetSetter[interface{}]{}, 0)
+	assert.Error(t, err)
+}
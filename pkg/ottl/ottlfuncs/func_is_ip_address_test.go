@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_IsIPAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   interface{}
+		expected bool
+	}{
+		{
+			name:     "IPv4",
+			target:   "192.168.1.10",
+			expected: true,
+		},
+		{
+			name:     "IPv4 loopback",
+			target:   "127.0.0.1",
+			expected: true,
+		},
+		{
+			name:     "IPv6",
+			target:   "2001:db8::68",
+			expected: true,
+		},
+		{
+			name:     "IPv4-mapped IPv6",
+			target:   "::ffff:192.0.2.1",
+			expected: true,
+		},
+		{
+			name:     "IPv4 out of range",
+			target:   "256.1.1.1",
+			expected: false,
+		},
+		{
+			name:     "IPv4 with port",
+			target:   "192.168.1.10:8080",
+			expected: false,
+		},
+		{
+			name:     "hostname",
+			target:   "localhost",
+			expected: false,
+		},
+		{
+			name:     "empty string",
+			target:   "",
+			expected: false,
+		},
+		{
+			name:     "not a string",
+			target:   int64(1),
+			expected: false,
+		},
+		{
+			name:     "nil",
+			target:   nil,
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := IsIPAddress[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			})
+			assert.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func IsSubsetOf[K any](subset ottl.Getter[K], superset ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		sub, err := getSubsetOperand(ctx, subset)
+		if err != nil {
+			return nil, err
+		}
+		super, err := getSubsetOperand(ctx, superset)
+		if err != nil {
+			return nil, err
+		}
+
+		isSubset := true
+		sub.Range(func(k string, v pcommon.Value) bool {
+			superVal, ok := super.Get(k)
+			isSubset = ok && superVal.Equal(v)
+			return isSubset
+		})
+		return isSubset, nil
+	}, nil
+}
+
+func getSubsetOperand[K any](ctx K, getter ottl.Getter[K]) (pcommon.Map, error) {
+	val, err := getter.Get(ctx)
+	if err != nil {
+		return pcommon.Map{}, err
+	}
+	m, ok := val.(pcommon.Map)
+	if !ok {
+		return pcommon.Map{}, fmt.Errorf("IsSubsetOf requires maps, but got %T", val)
+	}
+	return m, nil
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_isSubsetOf(t *testing.T) {
+	superset := pcommon.NewMap()
+	superset.PutStr("service.name", "checkout")
+	superset.PutInt("http.status_code", 200)
+	superset.PutBool("error", false)
+	superset.PutEmptySlice("tags").AppendEmpty().SetStr("a")
+
+	tests := []struct {
+		name     string
+		subset   func(pcommon.Map)
+		superset pcommon.Map
+		expected bool
+	}{
+		{
+			name: "subset",
+			subset: func(m pcommon.Map) {
+				m.PutStr("service.name", "checkout")
+				m.PutInt("http.status_code", 200)
+			},
+			superset: superset,
+			expected: true,
+		},
+		{
+			name: "equal maps",
+			subset: func(m pcommon.Map) {
+				superset.CopyTo(m)
+			},
+			superset: superset,
+			expected: true,
+		},
+		{
+			name: "different value",
+			subset: func(m pcommon.Map) {
+				m.PutStr("service.name", "checkout")
+				m.PutInt("http.status_code", 500)
+			},
+			superset: superset,
+			expected: false,
+		},
+		{
+			name: "different type",
+			subset: func(m pcommon.Map) {
+				m.PutStr("http.status_code", "200")
+			},
+			superset: superset,
+			expected: false,
+		},
+		{
+			name: "missing key",
+			subset: func(m pcommon.Map) {
+				m.PutStr("service.name", "checkout")
+				m.PutStr("host.name", "localhost")
+			},
+			superset: superset,
+			expected: false,
+		},
+		{
+			name:     "empty subset",
+			subset:   func(pcommon.Map) {},
+			superset: superset,
+			expected: true,
+		},
+		{
+			name:     "empty maps",
+			subset:   func(pcommon.Map) {},
+			superset: pcommon.NewMap(),
+			expected: true,
+		},
+		{
+			name: "empty superset",
+			subset: func(m pcommon.Map) {
+				m.PutStr("service.name", "checkout")
+			},
+			superset: pcommon.NewMap(),
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subset := pcommon.NewMap()
+			tt.subset(subset)
+			exprFunc, err := IsSubsetOf[interface{}](
+				&ottl.StandardGetSetter[interface{}]{
+					Getter: func(interface{}) (interface{}, error) {
+						return subset, nil
+					},
+				},
+				&ottl.StandardGetSetter[interface{}]{
+					Getter: func(interface{}) (interface{}, error) {
+						return tt.superset, nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_isSubsetOf_not_a_map(t *testing.T) {
+	m := pcommon.NewMap()
+	tests := []struct {
+		name     string
+		subset   interface{}
+		superset interface{}
+		expected string
+	}{
+		{
+			name:     "subset",
+			subset:   "a",
+			superset: m,
+			expected: "IsSubsetOf requires maps, but got string",
+		},
+		{
+			name:     "superset",
+			subset:   m,
+			superset: int64(1),
+			expected: "IsSubsetOf requires maps, but got int64",
+		},
+		{
+			name:     "nil",
+			subset:   nil,
+			superset: m,
+			expected: "IsSubsetOf requires maps, but got <nil>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := IsSubsetOf[interface{}](
+				&ottl.StandardGetSetter[interface{}]{
+					Getter: func(interface{}) (interface{}, error) {
+						return tt.subset, nil
+					},
+				},
+				&ottl.StandardGetSetter[interface{}]{
+					Getter: func(interface{}) (interface{}, error) {
+						return tt.superset, nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			_, err = exprFunc(nil)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const defaultMaskChar = "*"
+
+func Mask[K any](target ottl.Getter[K], keepStart int64, keepEnd int64, maskChar string) (ottl.ExprFunc[K], error) {
+	if keepStart < 0 || keepEnd < 0 {
+		return nil, fmt.Errorf("invalid number of runes to keep for Mask, %d and %d cannot be negative", keepStart, keepEnd)
+	}
+	if maskChar == "" {
+		maskChar = defaultMaskChar
+	}
+	if utf8.RuneCountInString(maskChar) != 1 {
+		return nil, fmt.Errorf("invalid mask character %q for Mask, must be a single character", maskChar)
+	}
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		valStr, ok := val.(string)
+		if !ok {
+			return nil, nil
+		}
+		runes := []rune(valStr)
+		n := int64(len(runes))
+		if keepStart+keepEnd >= n {
+			// Nothing would be masked, the whole value is masked instead so as not to reveal it.
+			return strings.Repeat(maskChar, len(runes)), nil
+		}
+		return string(runes[:keepStart]) + strings.Repeat(maskChar, int(n-keepStart-keepEnd)) + string(runes[n-keepEnd:]), nil
+	}, nil
+}
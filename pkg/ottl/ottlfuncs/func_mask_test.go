@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_mask(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     interface{}
+		keepStart int64
+		keepEnd   int64
+		maskChar  string
+		expected  interface{}
+	}{
+		{
+			name:     "credit card",
+			value:    "4111111111111111",
+			keepEnd:  4,
+			expected: "************1111",
+		},
+		{
+			name:      "keep start and end",
+			value:     "john.doe@example.com",
+			keepStart: 2,
+			keepEnd:   4,
+			maskChar:  "#",
+			expected:  "jo##############.com",
+		},
+		{
+			name:     "keep nothing",
+			value:    "secret",
+			expected: "******",
+		},
+		{
+			name:      "multi-byte runes",
+			value:     "héllo wörld",
+			keepStart: 1,
+			keepEnd:   1,
+			maskChar:  "•",
+			expected:  "h•••••••••d",
+		},
+		{
+			name:      "ranges touching",
+			value:     "abcd",
+			keepStart: 2,
+			keepEnd:   2,
+			expected:  "****",
+		},
+		{
+			name:      "ranges overlapping",
+			value:     "abc",
+			keepStart: 2,
+			keepEnd:   4,
+			expected:  "***",
+		},
+		{
+			name:      "empty string",
+			value:     "",
+			keepStart: 1,
+			keepEnd:   1,
+			expected:  "",
+		},
+		{
+			name:     "not a string",
+			value:    int64(4111),
+			keepEnd:  2,
+			expected: nil,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}
+			exprFunc, err := Mask[interface{}](target, tt.keepStart, tt.keepEnd, tt.maskChar)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_mask_validation(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{}
+	_, err := Mask[interface{}](target, -1, 0, "")
+	assert.EqualError(t, err, "invalid number of runes to keep for Mask, -1 and 0 cannot be negative")
+	_, err = Mask[interface{}](target, 0, 0, "**")
+	assert.EqualError(t, err, `invalid mask character "**" for Mask, must be a single character`)
+}
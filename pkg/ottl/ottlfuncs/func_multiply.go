@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Multiply[K any](target ottl.Getter[K], factor float64) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// An int64 stays an int64 as long as factor is a whole number and the result fits in an int64.
+		if v, ok := val.(int64); ok {
+			if whole, ok := toWholeInt64(factor); ok {
+				if result, ok := multiplyInt64(v, whole); ok {
+					return result, nil
+				}
+			}
+		}
+		f, err := toFloat64("Multiply", val)
+		if err != nil {
+			return nil, err
+		}
+		return f * factor, nil
+	}, nil
+}
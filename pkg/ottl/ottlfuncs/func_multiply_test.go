@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Multiply(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		factor   float64
+		expected interface{}
+	}{
+		{
+			name:     "int64 by whole factor",
+			value:    int64(1024),
+			factor:   8,
+			expected: int64(8192),
+		},
+		{
+			name:     "int64 by negative whole factor",
+			value:    int64(3),
+			factor:   -2,
+			expected: int64(-6),
+		},
+		{
+			name:     "int64 by fractional factor",
+			value:    int64(1500),
+			factor:   0.001,
+			expected: float64(1.5),
+		},
+		{
+			name:     "int64 by factor out of the int64 range",
+			value:    int64(2),
+			factor:   1e19,
+			expected: float64(2e19),
+		},
+		{
+			name:     "int64 overflowing by whole factor",
+			value:    int64(math.MaxInt64),
+			factor:   2,
+			expected: float64(math.MaxInt64) * 2,
+		},
+		{
+			name:     "min int64 by minus one",
+			value:    int64(math.MinInt64),
+			factor:   -1,
+			expected: -float64(math.MinInt64),
+		},
+		{
+			name:     "int64 by infinite factor",
+			value:    int64(2),
+			factor:   math.Inf(1),
+			expected: math.Inf(1),
+		},
+		{
+			name:     "float64 by whole factor",
+			value:    float64(1.5),
+			factor:   2,
+			expected: float64(3),
+		},
+		{
+			name:     "float64 by fractional factor",
+			value:    float64(10),
+			factor:   0.5,
+			expected: float64(5),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Multiply[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.factor)
+			assert.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Multiply_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{
+			name:  "string",
+			value: "2",
+		},
+		{
+			name:  "bool",
+			value: true,
+		},
+		{
+			name:  "nil",
+			value: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Multiply[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, 2)
+			assert.NoError(t, err)
+			_, err = exprFunc(nil)
+			assert.Error(t, err)
+		})
+	}
+}
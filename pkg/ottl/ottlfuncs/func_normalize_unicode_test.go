@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_NormalizeUnicode(t *testing.T) {
+	const (
+		composed   = "caf\u00e9"  // é as a single code point
+		decomposed = "cafe\u0301" // e followed by a combining acute accent
+	)
+	tests := []struct {
+		name     string
+		value    interface{}
+		form     string
+		expected interface{}
+	}{
+		{
+			name:     "NFC composes",
+			value:    decomposed,
+			form:     "NFC",
+			expected: composed,
+		},
+		{
+			name:     "NFC leaves composed characters",
+			value:    composed,
+			form:     "NFC",
+			expected: composed,
+		},
+		{
+			name:     "NFD decomposes",
+			value:    composed,
+			form:     "NFD",
+			expected: decomposed,
+		},
+		{
+			name:     "NFC keeps compatibility characters",
+			value:    "ﬁle",
+			form:     "NFC",
+			expected: "ﬁle",
+		},
+		{
+			name:     "NFKC replaces compatibility characters",
+			value:    "ﬁle ①",
+			form:     "NFKC",
+			expected: "file 1",
+		},
+		{
+			name:     "NFKD replaces compatibility characters and decomposes",
+			value:    "ﬁl" + composed,
+			form:     "NFKD",
+			expected: "fil" + decomposed,
+		},
+		{
+			name:     "byte slice",
+			value:    []byte(decomposed),
+			form:     "NFC",
+			expected: composed,
+		},
+		{
+			name:     "int",
+			value:    int64(42),
+			form:     "NFC",
+			expected: "42",
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			form:     "NFC",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := NormalizeUnicode[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.form)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_NormalizeUnicode_validation(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(interface{}) (interface{}, error) {
+			return "café", nil
+		},
+	}
+	for _, form := range []string{"", "nfc", "NFX"} {
+		exprFunc, err := NormalizeUnicode[interface{}](target, form)
+		assert.Error(t, err)
+		assert.Nil(t, exprFunc)
+	}
+}
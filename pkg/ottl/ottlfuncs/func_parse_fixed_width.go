@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func ParseFixedWidth[K any](target ottl.Getter[K], widths []int64, names []string) (ottl.ExprFunc[K], error) {
+	if len(widths) != len(names) {
+		return nil, fmt.Errorf("ParseFixedWidth requires as many widths as names, got %d widths and %d names", len(widths), len(names))
+	}
+	for _, width := range widths {
+		if width <= 0 {
+			return nil, fmt.Errorf("invalid width for ParseFixedWidth, %d must be positive", width)
+		}
+	}
+
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		valStr, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("ParseFixedWidth requires a string, got %T", val)
+		}
+		runes := []rune(valStr)
+		result := pcommon.NewMap()
+		result.EnsureCapacity(len(names))
+		start := int64(0)
+		for i, name := range names {
+			if start >= int64(len(runes)) {
+				break
+			}
+			end := start + widths[i]
+			if end > int64(len(runes)) {
+				end = int64(len(runes))
+			}
+			result.PutStr(name, string(runes[start:end]))
+			start = end
+		}
+		return result, nil
+	}, nil
+}
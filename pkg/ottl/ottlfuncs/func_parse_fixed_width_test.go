@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseFixedWidth(t *testing.T) {
+	widths := []int64{6, 3, 8}
+	names := []string{"account", "type", "amount"}
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]interface{}
+	}{
+		{
+			name:   "exact fit",
+			target: "000042DEP00012.50",
+			expected: map[string]interface{}{
+				"account": "000042",
+				"type":    "DEP",
+				"amount":  "00012.50",
+			},
+		},
+		{
+			name:   "padded fields",
+			target: "42    WD  12.5    ",
+			expected: map[string]interface{}{
+				"account": "42    ",
+				"type":    "WD ",
+				"amount":  " 12.5   ",
+			},
+		},
+		{
+			name:   "longer input",
+			target: "000042DEP00012.50EUR",
+			expected: map[string]interface{}{
+				"account": "000042",
+				"type":    "DEP",
+				"amount":  "00012.50",
+			},
+		},
+		{
+			name:   "short input",
+			target: "000042DEP000",
+			expected: map[string]interface{}{
+				"account": "000042",
+				"type":    "DEP",
+				"amount":  "000",
+			},
+		},
+		{
+			name:   "short input ending at a field boundary",
+			target: "000042",
+			expected: map[string]interface{}{
+				"account": "000042",
+			},
+		},
+		{
+			name:   "multi-byte characters",
+			target: "Zoë   ÉTÉ12.50",
+			expected: map[string]interface{}{
+				"account": "Zoë   ",
+				"type":    "ÉTÉ",
+				"amount":  "12.50",
+			},
+		},
+		{
+			name:     "empty string",
+			target:   "",
+			expected: map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseFixedWidth[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			}, widths, names)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			require.NoError(t, err)
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_ParseFixedWidth_validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		widths []int64
+		names  []string
+	}{
+		{
+			name:   "more widths than names",
+			widths: []int64{1, 2},
+			names:  []string{"a"},
+		},
+		{
+			name:   "more names than widths",
+			widths: []int64{1},
+			names:  []string{"a", "b"},
+		},
+		{
+			name:   "zero width",
+			widths: []int64{1, 0},
+			names:  []string{"a", "b"},
+		},
+		{
+			name:   "negative width",
+			widths: []int64{-1},
+			names:  []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFixedWidth[interface{}](&ottl.StandardGetSetter[interface{}]{}, tt.widths, tt.names)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_ParseFixedWidth_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target interface{}
+	}{
+		{
+			name:   "not a string",
+			target: int64(1),
+		},
+		{
+			name:   "nil",
+			target: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseFixedWidth[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			}, []int64{1}, []string{"a"})
+			require.NoError(t, err)
+			_, err = exprFunc(nil)
+			assert.Error(t, err)
+		})
+	}
+}
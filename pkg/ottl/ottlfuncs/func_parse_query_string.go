@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func ParseQueryString[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		valStr, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("ParseQueryString requires a string, got %T", val)
+		}
+		values, err := url.ParseQuery(valStr)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse query string: %w", err)
+		}
+		result := pcommon.NewMap()
+		result.EnsureCapacity(len(values))
+		for k, vs := range values {
+			if len(vs) == 1 {
+				result.PutStr(k, vs[0])
+				continue
+			}
+			s := result.PutEmptySlice(k)
+			s.EnsureCapacity(len(vs))
+			for _, v := range vs {
+				s.AppendEmpty().SetStr(v)
+			}
+		}
+		return result, nil
+	}, nil
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseQueryString(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]interface{}
+	}{
+		{
+			name:   "single values",
+			target: "a=1&b=2",
+			expected: map[string]interface{}{
+				"a": "1",
+				"b": "2",
+			},
+		},
+		{
+			name:   "repeated keys",
+			target: "tag=red&id=7&tag=green&tag=blue",
+			expected: map[string]interface{}{
+				"id":  "7",
+				"tag": []interface{}{"red", "green", "blue"},
+			},
+		},
+		{
+			name:   "url encoded values",
+			target: "q=hello+world&path=%2Fapi%2Fv1&emoji=%F0%9F%98%80&a%26b=c%3Dd",
+			expected: map[string]interface{}{
+				"q":     "hello world",
+				"path":  "/api/v1",
+				"emoji": "😀",
+				"a&b":   "c=d",
+			},
+		},
+		{
+			name:   "empty values",
+			target: "flag&empty=",
+			expected: map[string]interface{}{
+				"flag":  "",
+				"empty": "",
+			},
+		},
+		{
+			name:     "empty string",
+			target:   "",
+			expected: map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseQueryString[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			require.NoError(t, err)
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_ParseQueryString_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target interface{}
+	}{
+		{
+			name:   "invalid escape",
+			target: "a=%zz",
+		},
+		{
+			name:   "semicolon separator",
+			target: "a=1;b=2",
+		},
+		{
+			name:   "not a string",
+			target: int64(1),
+		},
+		{
+			name:   "nil",
+			target: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseQueryString[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
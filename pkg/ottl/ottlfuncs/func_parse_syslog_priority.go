@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	syslogFacilityKey = "facility"
+	syslogSeverityKey = "severity"
+
+	// maxSyslogPriority is the PRI of the last facility, local7, with the last severity, debug.
+	maxSyslogPriority = 191
+)
+
+func ParseSyslogPriority[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var pri int64
+		switch v := val.(type) {
+		case int64:
+			pri = v
+		case string:
+			pri, err = strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid syslog priority %q: %w", v, err)
+			}
+		default:
+			return nil, fmt.Errorf("ParseSyslogPriority requires an int or a string, got %T", val)
+		}
+		if pri < 0 || pri > maxSyslogPriority {
+			return nil, fmt.Errorf("invalid syslog priority %d, must be between 0 and %d", pri, maxSyslogPriority)
+		}
+
+		// RFC 5424 computes the PRI as the facility multiplied by 8, plus the severity.
+		result := pcommon.NewMap()
+		result.PutInt(syslogFacilityKey, pri/8)
+		result.PutInt(syslogSeverityKey, pri%8)
+		return result, nil
+	}, nil
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseSyslogPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:   "kernel emergency",
+			target: int64(0),
+			expected: map[string]interface{}{
+				"facility": int64(0),
+				"severity": int64(0),
+			},
+		},
+		{
+			name:   "auth critical",
+			target: int64(34),
+			expected: map[string]interface{}{
+				"facility": int64(4),
+				"severity": int64(2),
+			},
+		},
+		{
+			name:   "local4 notice",
+			target: int64(165),
+			expected: map[string]interface{}{
+				"facility": int64(20),
+				"severity": int64(5),
+			},
+		},
+		{
+			name:   "local7 debug",
+			target: int64(191),
+			expected: map[string]interface{}{
+				"facility": int64(23),
+				"severity": int64(7),
+			},
+		},
+		{
+			name:   "string",
+			target: "13",
+			expected: map[string]interface{}{
+				"facility": int64(1),
+				"severity": int64(5),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseSyslogPriority[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			require.NoError(t, err)
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_ParseSyslogPriority_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target interface{}
+	}{
+		{
+			name:   "negative",
+			target: int64(-1),
+		},
+		{
+			name:   "too large",
+			target: int64(192),
+		},
+		{
+			name:   "non-numeric string",
+			target: "error",
+		},
+		{
+			name:   "empty string",
+			target: "",
+		},
+		{
+			name:   "float",
+			target: 34.0,
+		},
+		{
+			name:   "nil",
+			target: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseSyslogPriority[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
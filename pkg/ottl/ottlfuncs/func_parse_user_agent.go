@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	userAgentBrowserKey = "browser"
+	userAgentOSKey      = "os"
+	userAgentDeviceKey  = "device"
+
+	deviceMobile  = "Mobile"
+	deviceTablet  = "Tablet"
+	deviceDesktop = "Desktop"
+)
+
+type userAgentRule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// browserRules are evaluated in order, as several browsers include the tokens of others in their user agent,
+// e.g. Edge and Opera also include "Chrome/" and Chrome also includes "Safari/".
+var browserRules = []userAgentRule{
+	{name: "Edge", pattern: regexp.MustCompile(`\b(?:Edg|EdgA|EdgiOS|Edge)/`)},
+	{name: "Opera", pattern: regexp.MustCompile(`\b(?:OPR|Opera)\b`)},
+	{name: "Samsung Internet", pattern: regexp.MustCompile(`\bSamsungBrowser/`)},
+	{name: "Chrome", pattern: regexp.MustCompile(`\b(?:Chrome|CriOS)/`)},
+	{name: "Firefox", pattern: regexp.MustCompile(`\b(?:Firefox|FxiOS)/`)},
+	{name: "Safari", pattern: regexp.MustCompile(`\bVersion/[\d.]+.*\bSafari/`)},
+	{name: "Internet Explorer", pattern: regexp.MustCompile(`\bMSIE |\bTrident/`)},
+}
+
+var osRules = []userAgentRule{
+	{name: "iOS", pattern: regexp.MustCompile(`\b(?:iPhone|iPad|iPod)\b`)},
+	{name: "Android", pattern: regexp.MustCompile(`\bAndroid\b`)},
+	{name: "Windows", pattern: regexp.MustCompile(`\bWindows\b`)},
+	{name: "Chrome OS", pattern: regexp.MustCompile(`\bCrOS\b`)},
+	{name: "Mac OS X", pattern: regexp.MustCompile(`\bMac OS X\b`)},
+	{name: "Linux", pattern: regexp.MustCompile(`\bLinux\b`)},
+}
+
+var (
+	tabletPattern = regexp.MustCompile(`\biPad\b|\bTablet\b`)
+	mobilePattern = regexp.MustCompile(`\b(?:iPhone|iPod|Mobile)\b`)
+)
+
+func ParseUserAgent[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if valStr, ok := val.(string); ok {
+			return parseUserAgent(valStr), nil
+		}
+		return nil, nil
+	}, nil
+}
+
+func parseUserAgent(ua string) pcommon.Map {
+	browser := matchUserAgentRule(browserRules, ua)
+	os := matchUserAgentRule(osRules, ua)
+
+	result := pcommon.NewMap()
+	result.PutStr(userAgentBrowserKey, browser)
+	result.PutStr(userAgentOSKey, os)
+	result.PutStr(userAgentDeviceKey, userAgentDevice(ua, os))
+	return result
+}
+
+func matchUserAgentRule(rules []userAgentRule, ua string) string {
+	for _, rule := range rules {
+		if rule.pattern.MatchString(ua) {
+			return rule.name
+		}
+	}
+	return ""
+}
+
+func userAgentDevice(ua string, os string) string {
+	switch {
+	case tabletPattern.MatchString(ua):
+		return deviceTablet
+	case mobilePattern.MatchString(ua):
+		return deviceMobile
+	case os == "Android":
+		// Android devices without the "Mobile" token are tablets.
+		return deviceTablet
+	case os != "":
+		return deviceDesktop
+	default:
+		return ""
+	}
+}
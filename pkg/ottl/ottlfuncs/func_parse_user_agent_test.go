@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ParseUserAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		ua       string
+		expected map[string]interface{}
+	}{
+		{
+			name: "chrome on windows",
+			ua:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.0.0 Safari/537.36",
+			expected: map[string]interface{}{
+				"browser": "Chrome",
+				"os":      "Windows",
+				"device":  "Desktop",
+			},
+		},
+		{
+			name: "edge on windows",
+			ua:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.0.0 Safari/537.36 Edg/107.0.1418.35",
+			expected: map[string]interface{}{
+				"browser": "Edge",
+				"os":      "Windows",
+				"device":  "Desktop",
+			},
+		},
+		{
+			name: "firefox on linux",
+			ua:   "Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:106.0) Gecko/20100101 Firefox/106.0",
+			expected: map[string]interface{}{
+				"browser": "Firefox",
+				"os":      "Linux",
+				"device":  "Desktop",
+			},
+		},
+		{
+			name: "safari on mac",
+			ua:   "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.1 Safari/605.1.15",
+			expected: map[string]interface{}{
+				"browser": "Safari",
+				"os":      "Mac OS X",
+				"device":  "Desktop",
+			},
+		},
+		{
+			name: "safari on iphone",
+			ua:   "Mozilla/5.0 (iPhone; CPU iPhone OS 16_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.1 Mobile/15E148 Safari/604.1",
+			expected: map[string]interface{}{
+				"browser": "Safari",
+				"os":      "iOS",
+				"device":  "Mobile",
+			},
+		},
+		{
+			name: "chrome on ipad",
+			ua:   "Mozilla/5.0 (iPad; CPU OS 16_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/107.0.5304.101 Mobile/15E148 Safari/604.1",
+			expected: map[string]interface{}{
+				"browser": "Chrome",
+				"os":      "iOS",
+				"device":  "Tablet",
+			},
+		},
+		{
+			name: "chrome on android phone",
+			ua:   "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/107.0.0.0 Mobile Safari/537.36",
+			expected: map[string]interface{}{
+				"browser": "Chrome",
+				"os":      "Android",
+				"device":  "Mobile",
+			},
+		},
+		{
+			name: "samsung internet on android tablet",
+			ua:   "Mozilla/5.0 (Linux; Android 12; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/19.0 Chrome/102.0.5005.125 Safari/537.36",
+			expected: map[string]interface{}{
+				"browser": "Samsung Internet",
+				"os":      "Android",
+				"device":  "Tablet",
+			},
+		},
+		{
+			name: "unknown user agent",
+			ua:   "curl/7.85.0",
+			expected: map[string]interface{}{
+				"browser": "",
+				"os":      "",
+				"device":  "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ParseUserAgent[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.ua, nil
+				},
+			})
+			assert.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_ParseUserAgent_non_string(t *testing.T) {
+	exprFunc, err := ParseUserAgent[interface{}](&ottl.StandardGetSetter[interface{}]{
+		Getter: func(interface{}) (interface{}, error) {
+			return int64(1), nil
+		},
+	})
+	assert.NoError(t, err)
+	result, err := exprFunc(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func ReplaceFirst[K any](target ottl.GetSetter[K], regexPattern string, replacement string) (ottl.ExprFunc[K], error) {
+	compiledPattern, err := regexp.Compile(regexPattern)
+	if err != nil {
+		return nil, fmt.Errorf("the regex pattern supplied to replace_first is not a valid pattern: %w", err)
+	}
+	return func(ctx K) (interface{}, error) {
+		originalVal, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if originalValStr, ok := originalVal.(string); ok {
+			if loc := compiledPattern.FindStringIndex(originalValStr); loc != nil {
+				updatedStr := originalValStr[:loc[0]] + replacement + originalValStr[loc[1]:]
+				err = target.Set(ctx, updatedStr)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		return nil, nil
+	}, nil
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_replaceFirst(t *testing.T) {
+	input := pcommon.NewValueStr("application passwd=sensitivedtata otherarg=notsensitive key1 key2")
+
+	target := &ottl.StandardGetSetter[pcommon.Value]{
+		Getter: func(ctx pcommon.Value) (interface{}, error) {
+			return ctx.Str(), nil
+		},
+		Setter: func(ctx pcommon.Value, val interface{}) error {
+			ctx.SetStr(val.(string))
+			return nil
+		},
+	}
+
+	tests := []struct {
+		name        string
+		pattern     string
+		replacement string
+		want        string
+	}{
+		{
+			name:        "replace regex match",
+			pattern:     `passwd\=[^\s]*(\s?)`,
+			replacement: "passwd=*** ",
+			want:        "application passwd=*** otherarg=notsensitive key1 key2",
+		},
+		{
+			name:        "no regex match",
+			pattern:     `nomatch\=[^\s]*(\s?)`,
+			replacement: "shouldnotbeinoutput",
+			want:        "application passwd=sensitivedtata otherarg=notsensitive key1 key2",
+		},
+		{
+			name:        "only the first of multiple regex matches",
+			pattern:     `key[^\s]*`,
+			replacement: "****",
+			want:        "application passwd=sensitivedtata otherarg=notsensitive **** key2",
+		},
+		{
+			name:        "replacement is literal",
+			pattern:     `(key)\d`,
+			replacement: "$1",
+			want:        "application passwd=sensitivedtata otherarg=notsensitive $1 key2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioValue := pcommon.NewValueStr(input.Str())
+
+			exprFunc, err := ReplaceFirst[pcommon.Value](target, tt.pattern, tt.replacement)
+			require.NoError(t, err)
+
+			result, err := exprFunc(scenarioValue)
+			assert.NoError(t, err)
+			assert.Nil(t, result)
+			assert.Equal(t, pcommon.NewValueStr(tt.want), scenarioValue)
+		})
+	}
+}
+
+func Test_replaceFirst_bad_input(t *testing.T) {
+	input := pcommon.NewValueInt(1)
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) (interface{}, error) {
+			return ctx, nil
+		},
+		Setter: func(ctx interface{}, val interface{}) error {
+			t.Errorf("nothing should be set in this scenario")
+			return nil
+		},
+	}
+
+	exprFunc, err := ReplaceFirst[interface{}](target, "regexp", "{replacement}")
+	require.NoError(t, err)
+
+	result, err := exprFunc(input)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, pcommon.NewValueInt(1), input)
+
+	result, err = exprFunc(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func Test_replaceFirst_invalid_pattern(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) (interface{}, error) {
+			t.Errorf("nothing should be received in this scenario")
+			return nil, nil
+		},
+		Setter: func(ctx interface{}, val interface{}) error {
+			t.Errorf("nothing should be set in this scenario")
+			return nil
+		},
+	}
+
+	_, err := ReplaceFirst[interface{}](target, "*", "{anything}")
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "error parsing regexp:")
+}
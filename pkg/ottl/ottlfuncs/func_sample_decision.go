@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func SampleDecision[K any](ratio float64) (ottl.ExprFunc[K], error) {
+	if ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("the ratio supplied to SampleDecision must be between 0 and 1, got %v", ratio)
+	}
+
+	// every invocation of the function draws from its own source, which isn't safe for concurrent use
+	var mu sync.Mutex
+	random := rand.New(rand.NewSource(time.Now().UnixNano())) // nolint:gosec
+	return func(K) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return random.Float64() < ratio, nil
+	}, nil
+}
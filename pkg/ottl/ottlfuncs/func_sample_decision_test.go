@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SampleDecision(t *testing.T) {
+	const calls = 100000
+	tests := []struct {
+		name  string
+		ratio float64
+	}{
+		{
+			name:  "never",
+			ratio: 0,
+		},
+		{
+			name:  "quarter",
+			ratio: 0.25,
+		},
+		{
+			name:  "half",
+			ratio: 0.5,
+		},
+		{
+			name:  "always",
+			ratio: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := SampleDecision[interface{}](tt.ratio)
+			require.NoError(t, err)
+
+			sampled := 0
+			for i := 0; i < calls; i++ {
+				result, err := exprFunc(nil)
+				require.NoError(t, err)
+				if result.(bool) {
+					sampled++
+				}
+			}
+			// the standard deviation of the observed rate is at most 0.0016 for 100000 calls
+			assert.InDelta(t, tt.ratio, float64(sampled)/calls, 0.01)
+		})
+	}
+}
+
+func Test_SampleDecision_validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		ratio float64
+	}{
+		{
+			name:  "negative ratio",
+			ratio: -0.1,
+		},
+		{
+			name:  "ratio above one",
+			ratio: 1.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := SampleDecision[interface{}](tt.ratio)
+			assert.Error(t, err)
+			assert.Nil(t, exprFunc)
+		})
+	}
+}
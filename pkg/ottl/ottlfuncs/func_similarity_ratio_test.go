@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_SimilarityRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected float64
+	}{
+		{
+			name:     "identical",
+			a:        "checkout-service",
+			b:        "checkout-service",
+			expected: 1,
+		},
+		{
+			name:     "both empty",
+			a:        "",
+			b:        "",
+			expected: 1,
+		},
+		{
+			name:     "one substitution",
+			a:        "kitten",
+			b:        "sitten",
+			expected: 1 - 1.0/6,
+		},
+		{
+			name:     "similar",
+			a:        "kitten",
+			b:        "sitting",
+			expected: 1 - 3.0/7,
+		},
+		{
+			name:     "multibyte runes",
+			a:        "café",
+			b:        "cafe",
+			expected: 0.75,
+		},
+		{
+			name:     "dissimilar",
+			a:        "abc",
+			b:        "xyz",
+			expected: 0,
+		},
+		{
+			name:     "one empty",
+			a:        "abc",
+			b:        "",
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := SimilarityRatio[interface{}](
+				&ottl.StandardGetSetter[interface{}]{
+					Getter: func(interface{}) (interface{}, error) {
+						return tt.a, nil
+					},
+				},
+				&ottl.StandardGetSetter[interface{}]{
+					Getter: func(interface{}) (interface{}, error) {
+						return tt.b, nil
+					},
+				},
+			)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 1e-9)
+		})
+	}
+}
+
+func Test_SimilarityRatio_error(t *testing.T) {
+	exprFunc, err := SimilarityRatio[interface{}](
+		&ottl.StandardGetSetter[interface{}]{
+			Getter: func(interface{}) (interface{}, error) {
+				return "abc", nil
+			},
+		},
+		&ottl.StandardGetSetter[interface{}]{
+			Getter: func(interface{}) (interface{}, error) {
+				return int64(1), nil
+			},
+		},
+	)
+	require.NoError(t, err)
+	_, err = exprFunc(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Since(t *testing.T) {
+	past := time.Now().Add(-time.Hour).UnixNano()
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) (interface{}, error) {
+			return past, nil
+		},
+	}
+	exprFunc, err := Since[interface{}](target)
+	require.NoError(t, err)
+
+	first, err := exprFunc(nil)
+	require.NoError(t, err)
+	second, err := exprFunc(nil)
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, first.(int64), time.Hour.Nanoseconds())
+	assert.GreaterOrEqual(t, second.(int64), first.(int64))
+}
+
+func Test_Since_future(t *testing.T) {
+	future := time.Now().Add(time.Hour).UnixNano()
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) (interface{}, error) {
+			return future, nil
+		},
+	}
+	exprFunc, err := Since[interface{}](target)
+	require.NoError(t, err)
+
+	result, err := exprFunc(nil)
+	require.NoError(t, err)
+	assert.Less(t, result.(int64), int64(0))
+}
+
+func Test_Since_error(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{
+			name:  "string",
+			value: "2022-11-01T00:00:00Z",
+		},
+		{
+			name:  "float",
+			value: 1.5,
+		},
+		{
+			name:  "nil",
+			value: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}
+			exprFunc, err := Since[interface{}](target)
+			require.NoError(t, err)
+
+			_, err = exprFunc(nil)
+			assert.Error(t, err)
+		})
+	}
+}
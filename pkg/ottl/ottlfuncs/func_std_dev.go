@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func StdDev[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slice, ok := val.(pcommon.Slice)
+		if !ok {
+			return nil, fmt.Errorf("StdDev requires a slice, got %T", val)
+		}
+
+		values := make([]float64, slice.Len())
+		var sum float64
+		for i := 0; i < slice.Len(); i++ {
+			v := slice.At(i)
+			switch v.Type() {
+			case pcommon.ValueTypeInt:
+				values[i] = float64(v.Int())
+			case pcommon.ValueTypeDouble:
+				values[i] = v.Double()
+			default:
+				return nil, fmt.Errorf("StdDev requires numeric elements, got %s at index %d", v.Type(), i)
+			}
+			sum += values[i]
+		}
+		if len(values) < 2 {
+			return 0.0, nil
+		}
+
+		// the population standard deviation is the square root of the mean squared deviation from the mean
+		mean := sum / float64(len(values))
+		var squaredDeviations float64
+		for _, v := range values {
+			squaredDeviations += (v - mean) * (v - mean)
+		}
+		return math.Sqrt(squaredDeviations / float64(len(values))), nil
+	}, nil
+}
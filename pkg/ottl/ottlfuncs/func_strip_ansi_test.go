@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_StripANSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   interface{}
+		expected interface{}
+	}{
+		{
+			name:     "colored level",
+			target:   "\x1b[31mERROR\x1b[0m connection refused",
+			expected: "ERROR connection refused",
+		},
+		{
+			name:     "bold and 256 colors",
+			target:   "\x1b[1;38;5;208mWARN\x1b[22;39m disk almost full",
+			expected: "WARN disk almost full",
+		},
+		{
+			name:     "cursor movement and erase line",
+			target:   "\x1b[2K\x1b[1Gprogress 100%",
+			expected: "progress 100%",
+		},
+		{
+			name:     "hyperlink",
+			target:   "see \x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\ for details",
+			expected: "see docs for details",
+		},
+		{
+			name:     "window title terminated by bell",
+			target:   "\x1b]0;build\x07done",
+			expected: "done",
+		},
+		{
+			name:     "two character escapes",
+			target:   "\x1bc\x1b7saved\x1b8",
+			expected: "saved",
+		},
+		{
+			name:     "plain text",
+			target:   "GET /api/v1/users 200 [12ms]",
+			expected: "GET /api/v1/users 200 [12ms]",
+		},
+		{
+			name:     "empty string",
+			target:   "",
+			expected: "",
+		},
+		{
+			name:     "bytes",
+			target:   []byte("\x1b[32mINFO\x1b[0m started"),
+			expected: "INFO started",
+		},
+		{
+			name:     "int",
+			target:   int64(42),
+			expected: "42",
+		},
+		{
+			name:     "nil",
+			target:   nil,
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := StripANSI[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Sum[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		slice, ok := val.(pcommon.Slice)
+		if !ok {
+			return nil, fmt.Errorf("Sum requires a slice, got %T", val)
+		}
+
+		// the sum stays an int as long as all the elements are ints
+		var intSum int64
+		var doubleSum float64
+		allInts := true
+		for i := 0; i < slice.Len(); i++ {
+			v := slice.At(i)
+			switch v.Type() {
+			case pcommon.ValueTypeInt:
+				intSum += v.Int()
+			case pcommon.ValueTypeDouble:
+				doubleSum += v.Double()
+				allInts = false
+			default:
+				return nil, fmt.Errorf("Sum requires numeric elements, got %s at index %d", v.Type(), i)
+			}
+		}
+		if allInts {
+			return intSum, nil
+		}
+		return float64(intSum) + doubleSum, nil
+	}, nil
+}
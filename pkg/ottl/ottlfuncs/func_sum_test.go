@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Sum(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []interface{}
+		expected interface{}
+	}{
+		{
+			name:     "ints",
+			values:   []interface{}{int64(1), int64(2), int64(39)},
+			expected: int64(42),
+		},
+		{
+			name:     "negative ints",
+			values:   []interface{}{int64(10), int64(-15)},
+			expected: int64(-5),
+		},
+		{
+			name:     "doubles",
+			values:   []interface{}{1.5, 2.25},
+			expected: 3.75,
+		},
+		{
+			name:     "mixed ints and doubles",
+			values:   []interface{}{int64(1), 0.5, int64(2)},
+			expected: 3.5,
+		},
+		{
+			name:     "whole doubles",
+			values:   []interface{}{int64(1), 2.0},
+			expected: 3.0,
+		},
+		{
+			name:     "single element",
+			values:   []interface{}{int64(7)},
+			expected: int64(7),
+		},
+		{
+			name:     "empty slice",
+			values:   []interface{}{},
+			expected: int64(0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Sum[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					slice := pcommon.NewSlice()
+					slice.FromRaw(tt.values)
+					return slice, nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Sum_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target func() interface{}
+	}{
+		{
+			name: "string element",
+			target: func() interface{} {
+				slice := pcommon.NewSlice()
+				slice.FromRaw([]interface{}{int64(1), "2"})
+				return slice
+			},
+		},
+		{
+			name: "bool element",
+			target: func() interface{} {
+				slice := pcommon.NewSlice()
+				slice.FromRaw([]interface{}{true})
+				return slice
+			},
+		},
+		{
+			name: "not a slice",
+			target: func() interface{} {
+				return int64(1)
+			},
+		},
+		{
+			name: "nil",
+			target: func() interface{} {
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := Sum[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target(), nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
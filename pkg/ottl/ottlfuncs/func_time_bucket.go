@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TimeBucket[K any](target ottl.Getter[K], width string) (ottl.ExprFunc[K], error) {
+	d, err := time.ParseDuration(width)
+	if err != nil {
+		return nil, fmt.Errorf("invalid width %q for TimeBucket: %w", width, err)
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("invalid width %q for TimeBucket, must be positive", width)
+	}
+	w := int64(d)
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if nanos, ok := val.(int64); ok {
+			// the division is floored, so that the bucket before the epoch is -1 rather than 0
+			bucket := nanos / w
+			if nanos%w < 0 {
+				bucket--
+			}
+			return bucket, nil
+		}
+		return nil, nil
+	}, nil
+}
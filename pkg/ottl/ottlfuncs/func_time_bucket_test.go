@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_TimeBucket(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		width    string
+		expected interface{}
+	}{
+		{
+			name:     "epoch",
+			value:    int64(0),
+			width:    "1m",
+			expected: int64(0),
+		},
+		{
+			name:     "last nanosecond of a bucket",
+			value:    time.Date(2022, 11, 7, 15, 4, 59, 999999999, time.UTC).UnixNano(),
+			width:    "5m",
+			expected: time.Date(2022, 11, 7, 15, 0, 0, 0, time.UTC).Unix() / 300,
+		},
+		{
+			name:     "first nanosecond of the next bucket",
+			value:    time.Date(2022, 11, 7, 15, 5, 0, 0, time.UTC).UnixNano(),
+			width:    "5m",
+			expected: time.Date(2022, 11, 7, 15, 0, 0, 0, time.UTC).Unix()/300 + 1,
+		},
+		{
+			name:     "sub-second width",
+			value:    int64(1250 * time.Millisecond),
+			width:    "500ms",
+			expected: int64(2),
+		},
+		{
+			name:     "before the epoch",
+			value:    int64(-1),
+			width:    "1h",
+			expected: int64(-1),
+		},
+		{
+			name:     "start of a bucket before the epoch",
+			value:    int64(-time.Hour),
+			width:    "1h",
+			expected: int64(-1),
+		},
+		{
+			name:     "not an int",
+			value:    "2022-11-07T15:42:27Z",
+			width:    "1m",
+			expected: nil,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			width:    "1m",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardGetSetter[interface{}]{
+				Getter: func(ctx interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}
+			exprFunc, err := TimeBucket[interface{}](target, tt.width)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_TimeBucket_invalidWidth(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(ctx interface{}) (interface{}, error) {
+			return int64(0), nil
+		},
+	}
+	for _, width := range []string{"", "5 minutes", "0s", "-1m"} {
+		_, err := TimeBucket[interface{}](target, width)
+		assert.Error(t, err, width)
+	}
+}
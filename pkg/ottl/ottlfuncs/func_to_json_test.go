@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ToJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   func() interface{}
+		expected string
+	}{
+		{
+			name: "flat map",
+			target: func() interface{} {
+				m := pcommon.NewMap()
+				m.PutStr("name", "checkout")
+				m.PutInt("status", 200)
+				m.PutBool("cached", false)
+				m.PutDouble("ratio", 0.5)
+				return m
+			},
+			expected: `{"cached":false,"name":"checkout","ratio":0.5,"status":200}`,
+		},
+		{
+			name: "nested map",
+			target: func() interface{} {
+				m := pcommon.NewMap()
+				inner := m.PutEmptyMap("http")
+				inner.PutStr("method", "GET")
+				inner.PutEmptyMap("request").PutInt("size", 42)
+				s := m.PutEmptySlice("tags")
+				s.AppendEmpty().SetStr("a")
+				s.AppendEmpty().SetEmptyMap().PutStr("b", "c")
+				return m
+			},
+			expected: `{"http":{"method":"GET","request":{"size":42}},"tags":["a",{"b":"c"}]}`,
+		},
+		{
+			name: "empty map",
+			target: func() interface{} {
+				return pcommon.NewMap()
+			},
+			expected: `{}`,
+		},
+		{
+			name: "slice",
+			target: func() interface{} {
+				s := pcommon.NewSlice()
+				s.AppendEmpty().SetInt(1)
+				s.AppendEmpty().SetStr("two")
+				s.AppendEmpty().SetEmptySlice().AppendEmpty().SetBool(true)
+				return s
+			},
+			expected: `[1,"two",[true]]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ToJSON[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target(), nil
+				},
+			})
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_ToJSON_ordering(t *testing.T) {
+	first := pcommon.NewMap()
+	first.PutStr("b", "2")
+	first.PutStr("a", "1")
+	first.PutEmptyMap("c").PutStr("z", "26")
+	second := pcommon.NewMap()
+	second.PutEmptyMap("c").PutStr("z", "26")
+	second.PutStr("a", "1")
+	second.PutStr("b", "2")
+
+	toJSON := func(m pcommon.Map) interface{} {
+		exprFunc, err := ToJSON[interface{}](&ottl.StandardGetSetter[interface{}]{
+			Getter: func(interface{}) (interface{}, error) {
+				return m, nil
+			},
+		})
+		require.NoError(t, err)
+		result, err := exprFunc(nil)
+		require.NoError(t, err)
+		return result
+	}
+
+	assert.Equal(t, `{"a":"1","b":"2","c":{"z":"26"}}`, toJSON(first))
+	assert.Equal(t, toJSON(first), toJSON(second))
+}
+
+func Test_ToJSON_error(t *testing.T) {
+	tests := []struct {
+		name   string
+		target interface{}
+	}{
+		{
+			name:   "string",
+			target: `{"foo":"bar"}`,
+		},
+		{
+			name:   "int",
+			target: int64(1),
+		},
+		{
+			name:   "nil",
+			target: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := ToJSON[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.target, nil
+				},
+			})
+			require.NoError(t, err)
+			_, err = exprFunc(nil)
+			assert.Error(t, err)
+		})
+	}
+}
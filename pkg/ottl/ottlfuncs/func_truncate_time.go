@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+var truncateTimeUnits = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+func TruncateTime[K any](target ottl.Getter[K], unit string) (ottl.ExprFunc[K], error) {
+	d, ok := truncateTimeUnits[unit]
+	if !ok {
+		return nil, fmt.Errorf("invalid unit %q for TruncateTime, must be one of second, minute, hour or day", unit)
+	}
+	return func(ctx K) (interface{}, error) {
+		val, err := target.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if nanos, ok := val.(int64); ok {
+			// days are truncated to midnight UTC, as Truncate operates on the time since the zero time
+			return time.Unix(0, nanos).Truncate(d).UnixNano(), nil
+		}
+		return nil, nil
+	}, nil
+}
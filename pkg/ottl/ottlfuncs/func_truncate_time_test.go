@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlfuncs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_TruncateTime(t *testing.T) {
+	ts := time.Date(2022, 11, 7, 15, 42, 27, 123456789, time.UTC).UnixNano()
+	tests := []struct {
+		name     string
+		value    interface{}
+		unit     string
+		expected interface{}
+	}{
+		{
+			name:     "second",
+			value:    ts,
+			unit:     "second",
+			expected: time.Date(2022, 11, 7, 15, 42, 27, 0, time.UTC).UnixNano(),
+		},
+		{
+			name:     "minute",
+			value:    ts,
+			unit:     "minute",
+			expected: time.Date(2022, 11, 7, 15, 42, 0, 0, time.UTC).UnixNano(),
+		},
+		{
+			name:     "hour",
+			value:    ts,
+			unit:     "hour",
+			expected: time.Date(2022, 11, 7, 15, 0, 0, 0, time.UTC).UnixNano(),
+		},
+		{
+			name:     "day",
+			value:    ts,
+			unit:     "day",
+			expected: time.Date(2022, 11, 7, 0, 0, 0, 0, time.UTC).UnixNano(),
+		},
+		{
+			name:     "already truncated",
+			value:    time.Date(2022, 11, 7, 0, 0, 0, 0, time.UTC).UnixNano(),
+			unit:     "day",
+			expected: time.Date(2022, 11, 7, 0, 0, 0, 0, time.UTC).UnixNano(),
+		},
+		{
+			name:     "before the epoch",
+			value:    time.Date(1969, 12, 31, 23, 59, 59, 500, time.UTC).UnixNano(),
+			unit:     "minute",
+			expected: time.Date(1969, 12, 31, 23, 59, 0, 0, time.UTC).UnixNano(),
+		},
+		{
+			name:     "not an int",
+			value:    "2022-11-07T15:42:27Z",
+			unit:     "second",
+			expected: nil,
+		},
+		{
+			name:     "nil",
+			value:    nil,
+			unit:     "second",
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := TruncateTime[interface{}](&ottl.StandardGetSetter[interface{}]{
+				Getter: func(interface{}) (interface{}, error) {
+					return tt.value, nil
+				},
+			}, tt.unit)
+			require.NoError(t, err)
+			result, err := exprFunc(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_TruncateTime_validation(t *testing.T) {
+	target := &ottl.StandardGetSetter[interface{}]{
+		Getter: func(interface{}) (interface{}, error) {
+			return int64(0), nil
+		},
+	}
+	for _, unit := range []string{"", "week", "Second", "ms"} {
+		exprFunc, err := TruncateTime[interface{}](target, unit)
+		assert.Error(t, err)
+		assert.Nil(t, exprFunc)
+	}
+}
@@ -16,6 +16,7 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/bmatcuk/doublestar/v3"
@@ -29,6 +30,13 @@ const (
 	defaultMaxConcurrentFiles = 1024
 )
 
+const (
+	// framingOctetCounting splits log entries framed with octet counting, as described in RFC 6587.
+	framingOctetCounting = "octet_counting"
+	// framingJSONLines splits log entries on the line breaks found outside of JSON strings.
+	framingJSONLines = "json_lines"
+)
+
 // NewConfig creates a new input config with default values
 func NewConfig() *Config {
 	return &Config{
@@ -53,15 +61,25 @@ type Config struct {
 	IncludeFileNameResolved bool                  `mapstructure:"include_file_name_resolved,omitempty"`
 	IncludeFilePathResolved bool                  `mapstructure:"include_file_path_resolved,omitempty"`
 	PollInterval            time.Duration         `mapstructure:"poll_interval,omitempty"`
+	PollJitter              float64               `mapstructure:"poll_jitter,omitempty"`
 	StartAt                 string                `mapstructure:"start_at,omitempty"`
+	InitialLines            int                   `mapstructure:"initial_lines,omitempty"`
 	FingerprintSize         helper.ByteSize       `mapstructure:"fingerprint_size,omitempty"`
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"`
+	MaxAge                  time.Duration         `mapstructure:"max_age,omitempty"`
+	Framing                 string                `mapstructure:"framing,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"`
 }
 
 // Build will build a file input operator from the supplied configuration
 func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error) {
+	return c.BuildWithTokenTransform(logger, emit, nil)
+}
+
+// BuildWithTokenTransform will build a file input operator from the supplied configuration,
+// which applies transform to each token before emitting it. A nil transform is ignored.
+func (c Config) BuildWithTokenTransform(logger *zap.SugaredLogger, emit EmitFunc, transform TokenTransformFunc) (*Manager, error) {
 	if emit == nil {
 		return nil, fmt.Errorf("must provide emit function")
 	}
@@ -90,10 +108,18 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, fmt.Errorf("`max_log_size` must be positive")
 	}
 
+	if c.PollJitter < 0 || c.PollJitter >= 1 {
+		return nil, fmt.Errorf("`poll_jitter` must be at least 0 and less than 1")
+	}
+
 	if c.MaxConcurrentFiles <= 1 {
 		return nil, fmt.Errorf("`max_concurrent_files` must be greater than 1")
 	}
 
+	if c.MaxAge < 0 {
+		return nil, fmt.Errorf("`max_age` must not be negative")
+	}
+
 	if c.FingerprintSize == 0 {
 		c.FingerprintSize = DefaultFingerprintSize
 	} else if c.FingerprintSize < MinFingerprintSize {
@@ -101,8 +127,14 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 	}
 
 	// Ensure that splitter is buildable
-	factory := newMultilineSplitterFactory(c.Splitter.EncodingConfig, c.Splitter.Flusher, c.Splitter.Multiline)
-	_, err := factory.Build(int(c.MaxLogSize))
+	factory, err := c.buildSplitterFactory()
+	if err != nil {
+		return nil, err
+	}
+	if transform != nil {
+		factory = newTransformSplitterFactory(factory, transform)
+	}
+	_, err = factory.Build(int(c.MaxLogSize))
 	if err != nil {
 		return nil, err
 	}
@@ -117,6 +149,14 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		return nil, fmt.Errorf("invalid start_at location '%s'", c.StartAt)
 	}
 
+	if c.InitialLines < 0 {
+		return nil, fmt.Errorf("`initial_lines` must not be negative")
+	}
+
+	if c.InitialLines > 0 && startAtBeginning {
+		return nil, fmt.Errorf("`initial_lines` can only be used when `start_at` is 'end'")
+	}
+
 	return &Manager{
 		SugaredLogger: logger.With("component", "fileconsumer"),
 		cancel:        func() {},
@@ -125,6 +165,7 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 			readerConfig: &readerConfig{
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
+				initialLines:    c.InitialLines,
 				emit:            emit,
 			},
 			fromBeginning:   startAtBeginning,
@@ -134,8 +175,31 @@ func (c Config) Build(logger *zap.SugaredLogger, emit EmitFunc) (*Manager, error
 		finder:        c.Finder,
 		roller:        newRoller(),
 		pollInterval:  c.PollInterval,
+		pollJitter:    c.PollJitter,
+		maxAge:        c.MaxAge,
+		rand:          rand.New(rand.NewSource(time.Now().UnixNano())), // nolint:gosec
 		maxBatchFiles: c.MaxConcurrentFiles / 2,
 		knownFiles:    make([]*Reader, 0, 10),
 		seenPaths:     make(map[string]struct{}, 100),
 	}, nil
 }
+
+// buildSplitterFactory returns the factory of the splitters for the framing, which splits on
+// newlines or on the multiline patterns by default.
+func (c Config) buildSplitterFactory() (splitterFactory, error) {
+	if c.Framing == "" {
+		return newMultilineSplitterFactory(c.Splitter.EncodingConfig, c.Splitter.Flusher, c.Splitter.Multiline), nil
+	}
+
+	if c.Splitter.Multiline.LineStartPattern != "" || c.Splitter.Multiline.LineEndPattern != "" {
+		return nil, fmt.Errorf("`multiline` can't be used with `framing`")
+	}
+	switch c.Framing {
+	case framingOctetCounting:
+		return newOctetCountingSplitterFactory(c.Splitter.Flusher), nil
+	case framingJSONLines:
+		return newJSONLinesSplitterFactory(c.Splitter.Flusher), nil
+	default:
+		return nil, fmt.Errorf("invalid framing '%s'", c.Framing)
+	}
+}
@@ -296,6 +296,38 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "initial_lines",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.InitialLines = 10
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "poll_jitter",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.PollJitter = 0.2
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "max_age",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.MaxAge = 24 * time.Hour
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "framing",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Framing = "octet_counting"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "max_concurrent_large",
 				Expect: func() *mockOperatorConfig {
@@ -432,6 +464,118 @@ func TestBuild(t *testing.T) {
 			require.NoError,
 			func(t *testing.T, f *Manager) {},
 		},
+		{
+			"InitialLinesAtEnd",
+			func(f *Config) {
+				f.StartAt = "end"
+				f.InitialLines = 10
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, 10, f.readerFactory.readerConfig.initialLines)
+			},
+		},
+		{
+			"InitialLinesAtBeginning",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.InitialLines = 10
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"NegativeInitialLines",
+			func(f *Config) {
+				f.InitialLines = -1
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"PollJitter",
+			func(f *Config) {
+				f.PollJitter = 0.2
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, 0.2, f.pollJitter)
+			},
+		},
+		{
+			"MaxAge",
+			func(f *Config) {
+				f.MaxAge = time.Hour
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.Equal(t, time.Hour, f.maxAge)
+			},
+		},
+		{
+			"NegativeMaxAge",
+			func(f *Config) {
+				f.MaxAge = -time.Hour
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"FramingOctetCounting",
+			func(f *Config) {
+				f.Framing = "octet_counting"
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.IsType(t, &octetCountingSplitterFactory{}, f.readerFactory.splitterFactory)
+			},
+		},
+		{
+			"FramingJSONLines",
+			func(f *Config) {
+				f.Framing = "json_lines"
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.IsType(t, &jsonLinesSplitterFactory{}, f.readerFactory.splitterFactory)
+			},
+		},
+		{
+			"InvalidFraming",
+			func(f *Config) {
+				f.Framing = "newline"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"FramingWithMultiline",
+			func(f *Config) {
+				f.Framing = "json_lines"
+				f.Splitter = helper.NewSplitterConfig()
+				f.Splitter.Multiline = helper.MultilineConfig{
+					LineStartPattern: "START.*",
+				}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"NegativePollJitter",
+			func(f *Config) {
+				f.PollJitter = -0.1
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"PollJitterTooLarge",
+			func(f *Config) {
+				f.PollJitter = 1
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidEncoding",
 			func(f *Config) {
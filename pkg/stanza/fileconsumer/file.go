@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"os"
 	"sync"
 	"time"
@@ -43,6 +44,13 @@ type Manager struct {
 	pollInterval  time.Duration
 	maxBatchFiles int
 
+	// pollJitter is the fraction of pollInterval by which the delay between polls is randomized.
+	pollJitter float64
+	rand       *rand.Rand
+
+	// maxAge, if positive, is the age of the last modification past which files are not read.
+	maxAge time.Duration
+
 	knownFiles []*Reader
 	seenPaths  map[string]struct{}
 }
@@ -88,7 +96,7 @@ func (m *Manager) startPoller(ctx context.Context) {
 	m.wg.Add(1)
 	go func() {
 		defer m.wg.Done()
-		globTicker := time.NewTicker(m.pollInterval)
+		globTicker := time.NewTicker(m.nextPollDelay())
 		defer globTicker.Stop()
 
 		for {
@@ -98,11 +106,24 @@ func (m *Manager) startPoller(ctx context.Context) {
 			case <-globTicker.C:
 			}
 
+			if m.pollJitter > 0 {
+				globTicker.Reset(m.nextPollDelay())
+			}
 			m.poll(ctx)
 		}
 	}()
 }
 
+// nextPollDelay returns the delay before the next poll, randomized within ±pollJitter of the poll interval
+// so that many consumers don't poll the filesystem in lockstep
+func (m *Manager) nextPollDelay() time.Duration {
+	if m.pollJitter == 0 {
+		return m.pollInterval
+	}
+	jitter := (2*m.rand.Float64() - 1) * m.pollJitter
+	return time.Duration(float64(m.pollInterval) * (1 + jitter))
+}
+
 // poll checks all the watched paths for new entries
 func (m *Manager) poll(ctx context.Context) {
 	// Increment the generation on all known readers
@@ -131,6 +152,11 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 
 	var wg sync.WaitGroup
 	for _, reader := range readers {
+		if m.tooOld(reader) {
+			// the reader is still saved with the known files, so that reading resumes at its offset
+			// once the file is modified again
+			continue
+		}
 		wg.Add(1)
 		go func(r *Reader) {
 			defer wg.Done()
@@ -223,6 +249,19 @@ OUTER:
 	return readers
 }
 
+// tooOld returns whether the file of the reader was last modified more than maxAge ago
+func (m *Manager) tooOld(reader *Reader) bool {
+	if m.maxAge <= 0 {
+		return false
+	}
+	info, err := reader.file.Stat()
+	if err != nil {
+		m.Errorw("Failed to stat file, reading it regardless of max_age", zap.Error(err))
+		return false
+	}
+	return time.Since(info.ModTime()) > m.maxAge
+}
+
 // saveCurrent adds the readers from this polling interval to this list of
 // known files, then increments the generation of all tracked old readers
 // before clearing out readers that have existed for 3 generations.
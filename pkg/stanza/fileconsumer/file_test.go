@@ -15,6 +15,7 @@
 package fileconsumer
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -232,7 +233,98 @@ func TestReadExistingLogs(t *testing.T) {
 	waitForToken(t, emitCalls, []byte("testlog2"))
 }
 
+// TestReadWithFraming tests that the log entries are split according to the framing
+func TestReadWithFraming(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		framing  string
+		content  string
+		expected [][]byte
+	}{
+		{
+			framing:  "octet_counting",
+			content:  "11 hello\nworld8 testlog2",
+			expected: [][]byte{[]byte("hello\nworld"), []byte("testlog2")},
+		},
+		{
+			framing:  "json_lines",
+			content:  "{\"msg\":\"hello\nworld\"}\n{\"msg\":\"testlog2\"}\n",
+			expected: [][]byte{[]byte("{\"msg\":\"hello\nworld\"}"), []byte("{\"msg\":\"testlog2\"}")},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.framing, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Framing = tc.framing
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			temp := openTemp(t, tempDir)
+			writeString(t, temp, tc.content)
+
+			require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			waitForTokens(t, emitCalls, tc.expected)
+		})
+	}
+}
+
+// TestReadOctetCountingMalformedFrame tests that a malformed frame doesn't
+// prevent the frames after it from being read
+func TestReadOctetCountingMalformedFrame(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Framing = "octet_counting"
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "5 firstx5 bad\n")
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("first"), []byte("x5 bad")})
+
+	writeString(t, temp, "6 second")
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("second"))
+	expectNoTokens(t, emitCalls)
+}
+
 // TestReadUsingNopEncoding tests when nop encoding is set, that the splitfunction returns all bytes unchanged.
+func TestReadWithTokenTransform(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	emitCalls := make(chan *emitParams, 100)
+	operator, err := cfg.BuildWithTokenTransform(testutil.Logger(t), func(_ context.Context, attrs *FileAttributes, token []byte) {
+		emitCalls <- &emitParams{attrs, token}
+	}, bytes.ToUpper)
+	require.NoError(t, err)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\ntestlog2\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("TESTLOG1"))
+	waitForToken(t, emitCalls, []byte("TESTLOG2"))
+}
+
 func TestReadUsingNopEncoding(t *testing.T) {
 	tcs := []struct {
 		testName string
@@ -462,6 +554,143 @@ func TestStartAtEnd(t *testing.T) {
 	waitForToken(t, emitCalls, []byte("testlog2"))
 }
 
+// TestPollJitter tests that the delays between polls vary within
+// the configured jitter around the poll interval
+func TestPollJitter(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.PollInterval = 100 * time.Millisecond
+	cfg.PollJitter = 0.2
+	operator, _ := buildTestManager(t, cfg)
+
+	delays := map[time.Duration]struct{}{}
+	for i := 0; i < 100; i++ {
+		delay := operator.nextPollDelay()
+		require.GreaterOrEqual(t, delay, 80*time.Millisecond)
+		require.LessOrEqual(t, delay, 120*time.Millisecond)
+		delays[delay] = struct{}{}
+	}
+	require.Greater(t, len(delays), 1)
+}
+
+// TestNoPollJitter tests that the delays between polls are the poll
+// interval when no jitter is configured
+func TestNoPollJitter(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.PollInterval = 100 * time.Millisecond
+	operator, _ := buildTestManager(t, cfg)
+
+	for i := 0; i < 10; i++ {
+		require.Equal(t, 100*time.Millisecond, operator.nextPollDelay())
+	}
+}
+
+// TestMaxAge tests that only the files modified within `max_age` are read,
+// and that files aging past it are no longer read until they are modified
+func TestMaxAge(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.MaxAge = time.Hour
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	old := openTemp(t, tempDir)
+	writeString(t, old, "old1\n")
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(old.Name(), twoHoursAgo, twoHoursAgo))
+
+	recent := openTemp(t, tempDir)
+	writeString(t, recent, "recent1\n")
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("recent1"))
+	expectNoTokens(t, emitCalls)
+
+	// the recent file ages past max_age, so the data written before is only read once it is modified again
+	writeString(t, recent, "recent2\n")
+	require.NoError(t, os.Chtimes(recent.Name(), twoHoursAgo, twoHoursAgo))
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+
+	writeString(t, recent, "recent3\n")
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("recent2"), []byte("recent3")})
+
+	// an old file is read once it is modified again
+	writeString(t, old, "old2\n")
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("old1"), []byte("old2")})
+	expectNoTokens(t, emitCalls)
+}
+
+// TestMaxAgeResumesAtOffset tests that a file which aged past `max_age` while
+// other files stayed active resumes where it was left once it is modified again
+func TestMaxAgeResumesAtOffset(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.MaxAge = time.Hour
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	aging := openTemp(t, tempDir)
+	writeString(t, aging, "aging1\naging2\n")
+	active := openTemp(t, tempDir)
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("aging1"), []byte("aging2")})
+
+	twoHoursAgo := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(aging.Name(), twoHoursAgo, twoHoursAgo))
+	for i := 0; i < 5; i++ {
+		line := fmt.Sprintf("active%d", i)
+		writeString(t, active, line+"\n")
+		operator.poll(context.Background())
+		waitForToken(t, emitCalls, []byte(line))
+	}
+
+	writeString(t, aging, "aging3\n")
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("aging3"))
+	expectNoTokens(t, emitCalls)
+}
+
+// TestStartAtEndInitialLines tests that when `start_at` is configured to `end`
+// and `initial_lines` is set, only the last lines of preexisting files are
+// read on the first poll
+func TestStartAtEndInitialLines(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.InitialLines = 2
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog1\ntestlog2\ntestlog3\ntestlog4\n")
+
+	// Expect only the last two lines on the first poll
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog3"), []byte("testlog4")})
+	expectNoTokens(t, emitCalls)
+
+	// Expect any new entries after the first poll
+	writeString(t, temp, "testlog5\n")
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog5"))
+}
+
 // StartAtEndNewFile tests that when `start_at` is configured to `end`,
 // a file created after the operator has been started is read from the
 // beginning
@@ -507,6 +736,31 @@ func TestNoNewline(t *testing.T) {
 	waitForToken(t, emitCalls, []byte("testlog2"))
 }
 
+// TestJSONLinesNoNewline tests that the last JSON line is still sent
+// eventually even if the file doesn't end in a newline
+func TestJSONLinesNoNewline(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Framing = "json_lines"
+	cfg.Splitter = helper.NewSplitterConfig()
+	cfg.Splitter.Flusher.Period = time.Nanosecond
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "{\"msg\":\"testlog1\"}\n{\"msg\":\"testlog2\"}")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	waitForToken(t, emitCalls, []byte("{\"msg\":\"testlog1\"}"))
+	waitForToken(t, emitCalls, []byte("{\"msg\":\"testlog2\"}"))
+}
+
 // TestEmptyLine tests that the any empty lines are consumed
 func TestEmptyLine(t *testing.T) {
 	t.Parallel()
@@ -16,8 +16,10 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -28,6 +30,7 @@ import (
 type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
+	initialLines    int
 	emit            EmitFunc
 }
 
@@ -55,6 +58,55 @@ func (r *Reader) offsetToEnd() error {
 	return nil
 }
 
+// readBackwardsChunkSize is the size of the chunks read when looking for the
+// last lines of a file
+const readBackwardsChunkSize = 4096
+
+// offsetToLastLines sets the starting offset to the beginning of the last n
+// newline-delimited lines of the file, or to the beginning of the file if it
+// contains fewer lines. A trailing newline does not count as an additional line.
+func (r *Reader) offsetToLastLines(n int) error {
+	info, err := r.file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat: %w", err)
+	}
+
+	// Skip the newline terminating the last line, if any
+	end := info.Size()
+	if end > 0 {
+		last := make([]byte, 1)
+		if _, err = r.file.ReadAt(last, end-1); err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+		if last[0] == '\n' {
+			end--
+		}
+	}
+
+	buf := make([]byte, readBackwardsChunkSize)
+	for end > 0 {
+		start := end - int64(len(buf))
+		if start < 0 {
+			start = 0
+		}
+		chunk := buf[:end-start]
+		if _, err = r.file.ReadAt(chunk, start); err != nil && err != io.EOF {
+			return fmt.Errorf("read: %w", err)
+		}
+		for i := bytes.LastIndexByte(chunk, '\n'); i >= 0; i = bytes.LastIndexByte(chunk[:i], '\n') {
+			n--
+			if n == 0 {
+				r.Offset = start + int64(i) + 1
+				return nil
+			}
+		}
+		end = start
+	}
+
+	r.Offset = 0
+	return nil
+}
+
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
 	if _, err := r.file.Seek(r.Offset, 0); err != nil {
@@ -119,7 +119,12 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
-			if err := r.offsetToEnd(); err != nil {
+			if b.readerConfig.initialLines > 0 {
+				err = r.offsetToLastLines(b.readerConfig.initialLines)
+			} else {
+				err = r.offsetToEnd()
+			}
+			if err != nil {
 				return nil, err
 			}
 		}
@@ -98,6 +98,69 @@ func TestTokenization(t *testing.T) {
 	}
 }
 
+func TestOffsetToLastLines(t *testing.T) {
+	longLine := string(tokenWithLength(readBackwardsChunkSize + 10))
+	testCases := []struct {
+		testName     string
+		fileContent  string
+		initialLines int
+		expected     string
+	}{
+		{
+			"fewer_lines_than_requested",
+			"testlog1\ntestlog2\n",
+			5,
+			"testlog1\ntestlog2\n",
+		},
+		{
+			"last_lines",
+			"testlog1\ntestlog2\ntestlog3\ntestlog4\n",
+			2,
+			"testlog3\ntestlog4\n",
+		},
+		{
+			"last_lines_without_trailing_newline",
+			"testlog1\ntestlog2\ntestlog3",
+			2,
+			"testlog2\ntestlog3",
+		},
+		{
+			"last_lines_across_chunks",
+			"testlog1\n" + longLine + "\ntestlog3\n",
+			2,
+			longLine + "\ntestlog3\n",
+		},
+		{
+			"empty_lines",
+			"testlog1\n\n\n",
+			2,
+			"\n\n",
+		},
+		{
+			"empty_file",
+			"",
+			2,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			f, _ := testReaderFactory(t)
+			f.fromBeginning = false
+			f.readerConfig.initialLines = tc.initialLines
+
+			temp := openTemp(t, t.TempDir())
+			_, err := temp.WriteString(tc.fileContent)
+			require.NoError(t, err)
+
+			r, err := f.newReaderBuilder().withFile(temp).build()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tc.fileContent[r.Offset:])
+		})
+	}
+}
+
 func testReaderFactory(t *testing.T) (*readerFactory, chan *emitParams) {
 	emitChan := make(chan *emitParams, 100)
 	return &readerFactory{
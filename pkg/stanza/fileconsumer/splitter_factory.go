@@ -16,6 +16,8 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"bufio"
+	"bytes"
+	"strconv"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
@@ -24,6 +26,11 @@ type splitterFactory interface {
 	Build(maxLogSize int) (bufio.SplitFunc, error)
 }
 
+// TokenTransformFunc transforms a token, e.g. by trimming or decoding it, before it is emitted.
+// The token is only valid until the function returns, so it must not be retained, but it may be
+// modified in place. Returning nil drops the token.
+type TokenTransformFunc func(token []byte) []byte
+
 type multilineSplitterFactory struct {
 	EncodingConfig helper.EncodingConfig
 	Flusher        helper.FlusherConfig
@@ -57,3 +64,204 @@ func (factory *multilineSplitterFactory) Build(maxLogSize int) (bufio.SplitFunc,
 	}
 	return splitter, nil
 }
+
+// transformSplitterFactory wraps the splitters of another factory, applying a transform to each token.
+type transformSplitterFactory struct {
+	splitterFactory
+	transform TokenTransformFunc
+}
+
+var _ splitterFactory = (*transformSplitterFactory)(nil)
+
+func newTransformSplitterFactory(factory splitterFactory, transform TokenTransformFunc) *transformSplitterFactory {
+	return &transformSplitterFactory{
+		splitterFactory: factory,
+		transform:       transform,
+	}
+}
+
+// Build builds the wrapped Splitter, applying the transform to the tokens it returns
+func (factory *transformSplitterFactory) Build(maxLogSize int) (bufio.SplitFunc, error) {
+	splitFunc, err := factory.splitterFactory.Build(maxLogSize)
+	if err != nil {
+		return nil, err
+	}
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		for {
+			n, token, err := splitFunc(data[advance:], atEOF)
+			if err != nil {
+				return 0, nil, err
+			}
+			advance += n
+			if token == nil {
+				return advance, nil, nil
+			}
+			if token = factory.transform(token); token != nil || n == 0 {
+				return advance, token, nil
+			}
+			// The token was dropped. Look for the next one right away, as bufio.Scanner
+			// stops scanning at EOF when no token is returned.
+		}
+	}, nil
+}
+
+// octetCountingSplitterFactory builds splitters for syslog messages framed with
+// octet counting as described in RFC 6587, where each message is preceded by its
+// length in bytes and a space, e.g. "11 hello world".
+type octetCountingSplitterFactory struct {
+	Flusher helper.FlusherConfig
+}
+
+var _ splitterFactory = (*octetCountingSplitterFactory)(nil)
+
+func newOctetCountingSplitterFactory(flusher helper.FlusherConfig) *octetCountingSplitterFactory {
+	return &octetCountingSplitterFactory{
+		Flusher: flusher,
+	}
+}
+
+// Build builds an octet counting Splitter
+func (factory *octetCountingSplitterFactory) Build(maxLogSize int) (bufio.SplitFunc, error) {
+	flusher := factory.Flusher.Build()
+	return flusher.SplitFunc(newOctetCountingSplitFunc(maxLogSize)), nil
+}
+
+// newOctetCountingSplitFunc creates a bufio.SplitFunc that reads the length prefix of a frame
+// and returns exactly that many bytes as a token. Line breaks between frames are skipped. A
+// malformed frame, or one exceeding maxLogSize, is returned as is up to the next line break,
+// so that the frames after it are still read.
+func newOctetCountingSplitFunc(maxLogSize int) bufio.SplitFunc {
+	maxPrefixLen := len(strconv.Itoa(maxLogSize))
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		start := 0
+		for start < len(data) && (data[start] == '\n' || data[start] == '\r') {
+			start++
+		}
+		if start == len(data) {
+			return start, nil, nil
+		}
+
+		space := bytes.IndexByte(data[start:], ' ')
+		prefix := data[start:]
+		if space >= 0 {
+			prefix = prefix[:space]
+		}
+		if !isOctetCount(prefix) || len(prefix) > maxPrefixLen {
+			return malformedOctetCountingFrame(data, start, atEOF, maxLogSize)
+		}
+		if space < 0 {
+			return start, nil, nil // read more data and try again.
+		}
+
+		msgLen, err := strconv.Atoi(string(prefix))
+		if err != nil || msgLen > maxLogSize {
+			return malformedOctetCountingFrame(data, start, atEOF, maxLogSize)
+		}
+
+		msgStart := start + space + 1
+		msgEnd := msgStart + msgLen
+		if len(data) < msgEnd {
+			return start, nil, nil // read more data and try again.
+		}
+		return msgEnd, data[msgStart:msgEnd], nil
+	}
+}
+
+// isOctetCount checks that b is a non-empty run of digits without a leading zero,
+// as required by the MSG-LEN field of RFC 6587.
+func isOctetCount(b []byte) bool {
+	if len(b) == 0 || b[0] == '0' {
+		return false
+	}
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// malformedOctetCountingFrame returns the malformed frame starting at start as a token, up to
+// the next line break or maxLogSize bytes, whichever comes first.
+func malformedOctetCountingFrame(data []byte, start int, atEOF bool, maxLogSize int) (advance int, token []byte, err error) {
+	end := len(data)
+	if end-start > maxLogSize {
+		end = start + maxLogSize
+	}
+	if i := bytes.IndexByte(data[start:end], '\n'); i >= 0 {
+		return start + i + 1, bytes.TrimRight(data[start:start+i], "\r"), nil
+	}
+	if end-start == maxLogSize || atEOF {
+		return end, data[start:end], nil
+	}
+	return start, nil, nil // read more data to find the end of the frame.
+}
+
+// jsonLinesSplitterFactory builds splitters for JSON lines, where each line holds a JSON value.
+// Unlike with the newline splitter, line breaks inside JSON strings, which some writers don't
+// escape, don't end a token.
+type jsonLinesSplitterFactory struct {
+	Flusher helper.FlusherConfig
+}
+
+var _ splitterFactory = (*jsonLinesSplitterFactory)(nil)
+
+func newJSONLinesSplitterFactory(flusher helper.FlusherConfig) *jsonLinesSplitterFactory {
+	return &jsonLinesSplitterFactory{
+		Flusher: flusher,
+	}
+}
+
+// Build builds a JSON lines Splitter
+func (factory *jsonLinesSplitterFactory) Build(maxLogSize int) (bufio.SplitFunc, error) {
+	flusher := factory.Flusher.Build()
+	return flusher.SplitFunc(newJSONLinesSplitFunc(maxLogSize)), nil
+}
+
+// newJSONLinesSplitFunc creates a bufio.SplitFunc that returns the lines of data as tokens,
+// only splitting on the line breaks found outside of JSON strings. Blank lines are skipped.
+func newJSONLinesSplitFunc(maxLogSize int) bufio.SplitFunc {
+	// The state of the scan at the start of data, which is only carried over from a previous
+	// call when a line was split at max_log_size in the middle of a string.
+	var inString, escaped bool
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		start := 0
+		if !inString {
+			// Skipped here rather than returned as empty tokens, as bufio.Scanner stops
+			// scanning at EOF when no token is returned.
+			for start < len(data) && isJSONWhitespace(data[start]) {
+				start++
+			}
+		}
+
+		lineInString, lineEscaped := inString, escaped
+		for i := start; i < len(data) && i < maxLogSize; i++ {
+			switch c := data[i]; {
+			case lineEscaped:
+				lineEscaped = false
+			case lineInString && c == '\\':
+				lineEscaped = true
+			case c == '"':
+				lineInString = !lineInString
+			case c == '\n' && !lineInString:
+				inString, escaped = false, false
+				return i + 1, bytes.TrimSpace(data[start:i]), nil
+			}
+		}
+
+		if start == len(data) {
+			return start, nil, nil
+		}
+		// The line is as large as the buffer can hold, so it's split like any other log entry
+		// exceeding max_log_size, keeping track of whether the split happened in a string.
+		if len(data) >= maxLogSize {
+			inString, escaped = lineInString, lineEscaped
+			return maxLogSize, data[start:maxLogSize], nil
+		}
+		return start, nil, nil // read more data and try again.
+	}
+}
+
+func isJSONWhitespace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
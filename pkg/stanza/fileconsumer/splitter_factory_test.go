@@ -15,9 +15,14 @@
 package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
 
 import (
+	"bufio"
+	"bytes"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
@@ -102,3 +107,327 @@ func Test_newMultilineSplitterFactory(t *testing.T) {
 	splitter := newMultilineSplitterFactory(helper.NewEncodingConfig(), helper.NewFlusherConfig(), helper.NewMultilineConfig())
 	assert.NotNil(t, splitter)
 }
+
+func Test_transformSplitterFactory_Build(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform TokenTransformFunc
+		input     string
+		expected  []string
+	}{
+		{
+			name:      "trim",
+			transform: bytes.TrimSpace,
+			input:     "  first  \n\tsecond\n",
+			expected:  []string{"first", "second"},
+		},
+		{
+			name: "decode",
+			transform: func(token []byte) []byte {
+				return bytes.ReplaceAll(token, []byte(`\t`), []byte("\t"))
+			},
+			input:    "a\\tb\nc\n",
+			expected: []string{"a\tb", "c"},
+		},
+		{
+			name: "drop",
+			transform: func(token []byte) []byte {
+				if bytes.HasPrefix(token, []byte("#")) {
+					return nil
+				}
+				return token
+			},
+			input:    "# comment\nfirst\n# another comment\nsecond\n",
+			expected: []string{"first", "second"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			multiline := newMultilineSplitterFactory(helper.NewEncodingConfig(), helper.NewFlusherConfig(), helper.NewMultilineConfig())
+			factory := newTransformSplitterFactory(multiline, tt.transform)
+			splitFunc, err := factory.Build(defaultMaxLogSize)
+			require.NoError(t, err)
+
+			scanner := bufio.NewScanner(strings.NewReader(tt.input))
+			scanner.Split(splitFunc)
+			var tokens []string
+			for scanner.Scan() {
+				tokens = append(tokens, scanner.Text())
+			}
+			require.NoError(t, scanner.Err())
+			assert.Equal(t, tt.expected, tokens)
+		})
+	}
+}
+
+func Test_transformSplitterFactory_BuildError(t *testing.T) {
+	multiline := newMultilineSplitterFactory(helper.EncodingConfig{Encoding: "error"}, helper.NewFlusherConfig(), helper.NewMultilineConfig())
+	factory := newTransformSplitterFactory(multiline, bytes.TrimSpace)
+	_, err := factory.Build(defaultMaxLogSize)
+	assert.Error(t, err)
+}
+
+func Test_octetCountingSplitterFactory_Build(t *testing.T) {
+	factory := newOctetCountingSplitterFactory(helper.NewFlusherConfig())
+	splitFunc, err := factory.Build(defaultMaxLogSize)
+	require.NoError(t, err)
+	assert.NotNil(t, splitFunc)
+}
+
+func Test_octetCountingSplitFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "single frame",
+			input:    "11 hello world",
+			expected: []string{"hello world"},
+		},
+		{
+			name:     "consecutive frames",
+			input:    "5 first6 second5 third",
+			expected: []string{"first", "second", "third"},
+		},
+		{
+			name:     "frames separated by line breaks",
+			input:    "5 first\n6 second\r\n5 third\n",
+			expected: []string{"first", "second", "third"},
+		},
+		{
+			name:     "frame containing line breaks and spaces",
+			input:    "13 line1\n line2 4 next",
+			expected: []string{"line1\n line2 ", "next"},
+		},
+		{
+			name:  "syslog message",
+			input: "81 <34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - 'su root' failed",
+			expected: []string{
+				"<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - 'su root' failed",
+			},
+		},
+		{
+			name:     "truncated frame",
+			input:    "5 first11 hello",
+			expected: []string{"first"},
+		},
+		{
+			name:     "truncated length prefix",
+			input:    "5 first12",
+			expected: []string{"first"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := bufio.NewScanner(strings.NewReader(tt.input))
+			scanner.Split(newOctetCountingSplitFunc(defaultMaxLogSize))
+			var tokens []string
+			for scanner.Scan() {
+				tokens = append(tokens, scanner.Text())
+			}
+			require.NoError(t, scanner.Err())
+			assert.Equal(t, tt.expected, tokens)
+		})
+	}
+}
+
+func Test_octetCountingSplitFunc_truncatedFrame(t *testing.T) {
+	splitFunc := newOctetCountingSplitFunc(defaultMaxLogSize)
+
+	// The frame is incomplete, so no token is returned until the rest of it is available.
+	for _, atEOF := range []bool{false, true} {
+		advance, token, err := splitFunc([]byte("11 hello"), atEOF)
+		require.NoError(t, err)
+		assert.Equal(t, 0, advance)
+		assert.Nil(t, token)
+	}
+
+	advance, token, err := splitFunc([]byte("11 hello world"), false)
+	require.NoError(t, err)
+	assert.Equal(t, 14, advance)
+	assert.Equal(t, []byte("hello world"), token)
+}
+
+func Test_octetCountingSplitFunc_malformed(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		maxLogSize int
+		expected   []string
+	}{
+		{
+			name:     "non numeric length",
+			input:    "abc hello\n5 first",
+			expected: []string{"abc hello", "first"},
+		},
+		{
+			name:     "negative length",
+			input:    "-5 hello\r\n5 first",
+			expected: []string{"-5 hello", "first"},
+		},
+		{
+			name:     "leading zero",
+			input:    "05 hello\n5 first",
+			expected: []string{"05 hello", "first"},
+		},
+		{
+			name:     "missing length",
+			input:    " hello\n5 first",
+			expected: []string{" hello", "first"},
+		},
+		{
+			name:     "length prefix too long",
+			input:    "12345678901234567890\n5 first",
+			expected: []string{"12345678901234567890", "first"},
+		},
+		{
+			name:     "length exceeds max log size",
+			input:    "2000000 hello\n5 first",
+			expected: []string{"2000000 hello", "first"},
+		},
+		{
+			name:     "malformed frame after valid frame",
+			input:    "5 firstx5 hello\n6 second",
+			expected: []string{"first", "x5 hello", "second"},
+		},
+		{
+			name:     "malformed frame at end of file",
+			input:    "5 firstx5 hello",
+			expected: []string{"first", "x5 hello"},
+		},
+		{
+			name:       "malformed frame without line break exceeds max log size",
+			input:      "x123456789012\n5 first",
+			maxLogSize: 10,
+			expected:   []string{"x123456789", "012", "first"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxLogSize := defaultMaxLogSize
+			if tt.maxLogSize > 0 {
+				maxLogSize = tt.maxLogSize
+			}
+			scanner := bufio.NewScanner(strings.NewReader(tt.input))
+			scanner.Split(newOctetCountingSplitFunc(maxLogSize))
+			var tokens []string
+			for scanner.Scan() {
+				tokens = append(tokens, scanner.Text())
+			}
+			require.NoError(t, scanner.Err())
+			assert.Equal(t, tt.expected, tokens)
+		})
+	}
+}
+
+func Test_jsonLinesSplitterFactory_Build(t *testing.T) {
+	factory := newJSONLinesSplitterFactory(helper.NewFlusherConfig())
+	splitFunc, err := factory.Build(defaultMaxLogSize)
+	require.NoError(t, err)
+	assert.NotNil(t, splitFunc)
+}
+
+func Test_framingSplitterFactory_ForceFlush(t *testing.T) {
+	flusher := helper.FlusherConfig{Period: time.Millisecond}
+	tests := []struct {
+		name    string
+		factory splitterFactory
+		// data is the last line, which isn't terminated
+		data string
+	}{
+		{
+			name:    "octet counting",
+			factory: newOctetCountingSplitterFactory(flusher),
+			data:    `11 {"a":"b"}`,
+		},
+		{
+			name:    "json lines",
+			factory: newJSONLinesSplitterFactory(flusher),
+			data:    `{"a":"b"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			splitFunc, err := tt.factory.Build(defaultMaxLogSize)
+			require.NoError(t, err)
+
+			// The last line is only returned once force_flush_period elapses.
+			advance, token, err := splitFunc([]byte(tt.data), false)
+			require.NoError(t, err)
+			assert.Equal(t, 0, advance)
+			assert.Nil(t, token)
+
+			time.Sleep(10 * time.Millisecond)
+			advance, token, err = splitFunc([]byte(tt.data), false)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.data), advance)
+			assert.Equal(t, []byte(tt.data), token)
+		})
+	}
+}
+
+func Test_jsonLinesSplitFunc(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		maxLogSize int
+		expected   []string
+	}{
+		{
+			name:     "single line",
+			input:    `{"message":"hello world"}` + "\n",
+			expected: []string{`{"message":"hello world"}`},
+		},
+		{
+			name:  "newlines inside strings",
+			input: "{\"message\":\"line1\nline2\"}\n{\"stack\":\"at main\n\tat run\",\"level\":\"error\"}\n",
+			expected: []string{
+				"{\"message\":\"line1\nline2\"}",
+				"{\"stack\":\"at main\n\tat run\",\"level\":\"error\"}",
+			},
+		},
+		{
+			name:  "escaped quotes inside strings",
+			input: "{\"message\":\"say \\\"hi\nthere\\\"\"}\n{\"path\":\"C:\\\\\"}\n{\"next\":true}\n",
+			expected: []string{
+				"{\"message\":\"say \\\"hi\nthere\\\"\"}",
+				"{\"path\":\"C:\\\\\"}",
+				`{"next":true}`,
+			},
+		},
+		{
+			name:     "carriage returns and blank lines",
+			input:    "{\"a\":1}\r\n\r\n\n{\"b\":\"x\r\ny\"}\r\n",
+			expected: []string{`{"a":1}`, "{\"b\":\"x\r\ny\"}"},
+		},
+		{
+			name:     "unterminated line",
+			input:    "{\"a\":1}\n{\"b\":\"still\nbeing written",
+			expected: []string{`{"a":1}`},
+		},
+		{
+			name:       "line exceeding max log size",
+			input:      `{"message":"0123456789"}` + "\n" + `{"a":1}` + "\n",
+			maxLogSize: 16,
+			expected:   []string{`{"message":"0123`, `456789"}`, `{"a":1}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxLogSize := tt.maxLogSize
+			if maxLogSize == 0 {
+				maxLogSize = defaultMaxLogSize
+			}
+			scanner := bufio.NewScanner(strings.NewReader(tt.input))
+			scanner.Buffer(make([]byte, 0, maxLogSize), maxLogSize)
+			scanner.Split(newJSONLinesSplitFunc(maxLogSize))
+			var tokens []string
+			for scanner.Scan() {
+				tokens = append(tokens, scanner.Text())
+			}
+			require.NoError(t, scanner.Err())
+			assert.Equal(t, tt.expected, tokens)
+		})
+	}
+}
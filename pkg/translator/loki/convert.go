@@ -16,6 +16,7 @@ package loki // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,11 +31,30 @@ const (
 	hintResources  = "loki.resource.labels"
 	hintTenant     = "loki.tenant"
 	hintFormat     = "loki.format"
+	hintLineFields = "loki.line.fields"
+	hintSeverity   = "loki.severity.label"
+	hintScope      = "loki.scope.labels"
+	hintMetadata   = "loki.metadata.labels"
+	hintConflict   = "loki.label.conflict"
+	hintTypes      = "loki.preserve.types"
 )
 
+const (
+	scopeFieldName    = "name"
+	scopeFieldVersion = "version"
+
+	labelScopeName    = "scope_name"
+	labelScopeVersion = "scope_version"
+)
+
+// conflictResource is the value of the conflict hint making the resource attributes win over the log attributes
+// promoted to labels of the same name. The log attributes win otherwise.
+const conflictResource = "resource"
+
 const (
 	formatJSON   string = "json"
 	formatLogfmt string = "logfmt"
+	formatOTLP   string = "otlp"
 )
 
 var defaultExporterLabels = model.LabelSet{"exporter": "OTLP"}
@@ -45,14 +65,20 @@ func convertAttributesAndMerge(logAttrs pcommon.Map, resAttrs pcommon.Map) model
 	// get the hint from the log attributes, not from the resource
 	// the value can be a single resource name to use as label
 	// or a slice of string values
+	var resourceLabels, attributeLabels model.LabelSet
 	if resourcesToLabel, found := logAttrs.Get(hintResources); found {
-		labels := convertAttributesToLabels(resAttrs, resourcesToLabel)
-		out = out.Merge(labels)
+		resourceLabels = convertAttributesToLabels(resAttrs, resourcesToLabel)
 	}
 
 	if attributesToLabel, found := logAttrs.Get(hintAttributes); found {
-		labels := convertAttributesToLabels(logAttrs, attributesToLabel)
-		out = out.Merge(labels)
+		attributeLabels = convertAttributesToLabels(logAttrs, attributesToLabel)
+	}
+
+	// the labels merged last win when a resource and a log attribute have the same name
+	if conflict, found := logAttrs.Get(hintConflict); found && conflict.Str() == conflictResource {
+		out = out.Merge(attributeLabels).Merge(resourceLabels)
+	} else {
+		out = out.Merge(resourceLabels).Merge(attributeLabels)
 	}
 
 	// get tenant hint from resource attributes, fallback to record attributes
@@ -70,6 +96,86 @@ func convertAttributesAndMerge(logAttrs pcommon.Map, resAttrs pcommon.Map) model
 	return out
 }
 
+// convertAttributesToMetadata returns the log attributes listed in the metadata hint, which are sent as
+// the structured metadata of the entry rather than as labels.
+func convertAttributesToMetadata(logAttrs pcommon.Map) model.LabelSet {
+	attributesToMetadata, found := logAttrs.Get(hintMetadata)
+	if !found {
+		return nil
+	}
+	return convertAttributesToLabels(logAttrs, attributesToMetadata)
+}
+
+// convertSeverityToLabel returns the severity of the log record as the label named by the severity hint.
+// The severity text is used when set, otherwise the severity number is mapped to the name of its range,
+// so that e.g. SEVERITY_NUMBER_ERROR2 becomes "error". Log records without severity get no label.
+func convertSeverityToLabel(lr plog.LogRecord) model.LabelSet {
+	labelVal, found := lr.Attributes().Get(hintSeverity)
+	if !found {
+		return nil
+	}
+	label := strings.TrimSpace(labelVal.AsString())
+	if label == "" {
+		return nil
+	}
+
+	severity := strings.ToLower(strings.TrimSpace(lr.SeverityText()))
+	if severity == "" {
+		severity = severityNumberToText(lr.SeverityNumber())
+	}
+	if severity == "" {
+		return nil
+	}
+	return model.LabelSet{model.LabelName(label): model.LabelValue(severity)}
+}
+
+// convertScopeToLabels returns the fields of the instrumentation scope listed in the scope hint, either
+// "name" or "version", as the "scope_name" and "scope_version" labels. Empty fields get no label.
+func convertScopeToLabels(logAttrs pcommon.Map, scope pcommon.InstrumentationScope) model.LabelSet {
+	fieldsVal, found := logAttrs.Get(hintScope)
+	if !found {
+		return nil
+	}
+
+	out := model.LabelSet{}
+	for _, field := range parseAttributeNames(fieldsVal) {
+		switch strings.TrimSpace(field) {
+		case scopeFieldName:
+			if scope.Name() != "" {
+				out[labelScopeName] = model.LabelValue(scope.Name())
+			}
+		case scopeFieldVersion:
+			if scope.Version() != "" {
+				out[labelScopeVersion] = model.LabelValue(scope.Version())
+			}
+		}
+	}
+	return out
+}
+
+// severityNumberToText returns the name of the range the severity number belongs to, each range
+// spanning four severity numbers, e.g. 17 to 20 for ERROR to ERROR4.
+func severityNumberToText(sn plog.SeverityNumber) string {
+	switch {
+	case sn > plog.SeverityNumberFatal4:
+		return ""
+	case sn >= plog.SeverityNumberFatal:
+		return "fatal"
+	case sn >= plog.SeverityNumberError:
+		return "error"
+	case sn >= plog.SeverityNumberWarn:
+		return "warn"
+	case sn >= plog.SeverityNumberInfo:
+		return "info"
+	case sn >= plog.SeverityNumberDebug:
+		return "debug"
+	case sn >= plog.SeverityNumberTrace:
+		return "trace"
+	default:
+		return ""
+	}
+}
+
 func convertAttributesToLabels(attributes pcommon.Map, attrsToSelect pcommon.Value) model.LabelSet {
 	out := model.LabelSet{}
 
@@ -106,7 +212,7 @@ func parseAttributeNames(attrsToSelect pcommon.Value) []string {
 
 func removeAttributes(attrs pcommon.Map, labels model.LabelSet) {
 	attrs.RemoveIf(func(s string, v pcommon.Value) bool {
-		if s == hintAttributes || s == hintResources || s == hintTenant || s == hintFormat {
+		if s == hintAttributes || s == hintResources || s == hintTenant || s == hintFormat || s == hintLineFields || s == hintSeverity || s == hintScope || s == hintMetadata || s == hintConflict || s == hintTypes {
 			return true
 		}
 
@@ -115,8 +221,31 @@ func removeAttributes(attrs pcommon.Map, labels model.LabelSet) {
 	})
 }
 
-func convertLogToJSONEntry(lr plog.LogRecord, res pcommon.Resource) (*logproto.Entry, error) {
-	line, err := Encode(lr, res)
+// getLineFieldsFromHint returns the names of the log attributes listed in the line fields hint.
+func getLineFieldsFromHint(logAttrs pcommon.Map) []string {
+	fieldsVal, found := logAttrs.Get(hintLineFields)
+	if !found {
+		return nil
+	}
+	fields := parseAttributeNames(fieldsVal)
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields
+}
+
+// getPreserveTypesFromHint returns whether the preserve types hint is set on the log attributes.
+func getPreserveTypesFromHint(logAttrs pcommon.Map) bool {
+	typesVal, found := logAttrs.Get(hintTypes)
+	if !found {
+		return false
+	}
+	preserve, err := strconv.ParseBool(typesVal.AsString())
+	return err == nil && preserve
+}
+
+func convertLogToJSONEntry(lr plog.LogRecord, res pcommon.Resource, lineFields []string, preserveTypes bool) (*logproto.Entry, error) {
+	line, err := encodeJSON(lr, res, lineFields, preserveTypes)
 	if err != nil {
 		return nil, err
 	}
@@ -137,14 +266,27 @@ func convertLogToLogfmtEntry(lr plog.LogRecord, res pcommon.Resource) (*logproto
 	}, nil
 }
 
-func convertLogToLokiEntry(lr plog.LogRecord, res pcommon.Resource, format string) (*logproto.Entry, error) {
+func convertLogToOTLPEntry(lr plog.LogRecord, scope pcommon.InstrumentationScope, res pcommon.Resource) (*logproto.Entry, error) {
+	line, err := EncodeOTLP(lr, scope, res)
+	if err != nil {
+		return nil, err
+	}
+	return &logproto.Entry{
+		Timestamp: timestampFromLogRecord(lr),
+		Line:      line,
+	}, nil
+}
+
+func convertLogToLokiEntry(lr plog.LogRecord, scope pcommon.InstrumentationScope, res pcommon.Resource, format string, lineFields []string, preserveTypes bool) (*logproto.Entry, error) {
 	switch format {
 	case formatJSON:
-		return convertLogToJSONEntry(lr, res)
+		return convertLogToJSONEntry(lr, res, lineFields, preserveTypes)
 	case formatLogfmt:
 		return convertLogToLogfmtEntry(lr, res)
+	case formatOTLP:
+		return convertLogToOTLPEntry(lr, scope, res)
 	default:
-		return nil, fmt.Errorf("invalid format %s. Expected one of: %s, %s", format, formatJSON, formatLogfmt)
+		return nil, fmt.Errorf("invalid format %s. Expected one of: %s, %s, %s", format, formatJSON, formatLogfmt, formatOTLP)
 	}
 
 }
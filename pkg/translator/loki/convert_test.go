@@ -20,6 +20,7 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 )
 
 func TestConvertAttributesAndMerge(t *testing.T) {
@@ -75,6 +76,38 @@ func TestConvertAttributesAndMerge(t *testing.T) {
 				"host.name": "hostname-from-attributes",
 			},
 		},
+		{
+			desc: "selected attributes from both sources should have the resource win with the conflict hint",
+			logAttrs: map[string]interface{}{
+				"host.name":    "hostname-from-attributes",
+				hintAttributes: "host.name",
+				hintResources:  "host.name",
+				hintConflict:   "resource",
+			},
+			resAttrs: map[string]interface{}{
+				"host.name": "hostname-from-resources",
+			},
+			expected: model.LabelSet{
+				"exporter":  "OTLP",
+				"host.name": "hostname-from-resources",
+			},
+		},
+		{
+			desc: "selected attributes from both sources should have the attribute win with the conflict hint",
+			logAttrs: map[string]interface{}{
+				"host.name":    "hostname-from-attributes",
+				hintAttributes: "host.name",
+				hintResources:  "host.name",
+				hintConflict:   "attribute",
+			},
+			resAttrs: map[string]interface{}{
+				"host.name": "hostname-from-resources",
+			},
+			expected: model.LabelSet{
+				"exporter":  "OTLP",
+				"host.name": "hostname-from-attributes",
+			},
+		},
 		{
 			desc: "it should be possible to override the exporter label",
 			logAttrs: map[string]interface{}{
@@ -156,6 +189,129 @@ func TestConvertAttributesToLabels(t *testing.T) {
 	}
 }
 
+func TestConvertSeverityToLabel(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		hint     interface{}
+		text     string
+		number   plog.SeverityNumber
+		expected model.LabelSet
+	}{
+		{
+			desc:     "severity text",
+			hint:     "level",
+			text:     " WARNING ",
+			number:   plog.SeverityNumberWarn,
+			expected: model.LabelSet{"level": "warning"},
+		},
+		{
+			desc:     "severity number",
+			hint:     "level",
+			number:   plog.SeverityNumberError,
+			expected: model.LabelSet{"level": "error"},
+		},
+		{
+			desc:     "severity number in the middle of a range",
+			hint:     "severity",
+			number:   plog.SeverityNumberDebug3,
+			expected: model.LabelSet{"severity": "debug"},
+		},
+		{
+			desc:     "highest severity number",
+			hint:     "level",
+			number:   plog.SeverityNumberFatal4,
+			expected: model.LabelSet{"level": "fatal"},
+		},
+		{
+			desc:   "no severity",
+			hint:   "level",
+			number: plog.SeverityNumberUnspecified,
+		},
+		{
+			desc:   "no hint",
+			text:   "INFO",
+			number: plog.SeverityNumberInfo,
+		},
+		{
+			desc:   "empty hint",
+			hint:   " ",
+			text:   "INFO",
+			number: plog.SeverityNumberInfo,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			lr := plog.NewLogRecord()
+			if tC.hint != nil {
+				lr.Attributes().FromRaw(map[string]interface{}{hintSeverity: tC.hint})
+			}
+			lr.SetSeverityText(tC.text)
+			lr.SetSeverityNumber(tC.number)
+			assert.Equal(t, tC.expected, convertSeverityToLabel(lr))
+		})
+	}
+}
+
+func TestConvertScopeToLabels(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		hint     interface{}
+		name     string
+		version  string
+		expected model.LabelSet
+	}{
+		{
+			desc:     "name and version",
+			hint:     "name, version",
+			name:     "io.opentelemetry.example",
+			version:  "1.0.0",
+			expected: model.LabelSet{"scope_name": "io.opentelemetry.example", "scope_version": "1.0.0"},
+		},
+		{
+			desc:     "name only",
+			hint:     "name",
+			name:     "io.opentelemetry.example",
+			version:  "1.0.0",
+			expected: model.LabelSet{"scope_name": "io.opentelemetry.example"},
+		},
+		{
+			desc:     "hint as slice",
+			hint:     []interface{}{"version"},
+			name:     "io.opentelemetry.example",
+			version:  "1.0.0",
+			expected: model.LabelSet{"scope_version": "1.0.0"},
+		},
+		{
+			desc:     "empty name",
+			hint:     "name, version",
+			version:  "1.0.0",
+			expected: model.LabelSet{"scope_version": "1.0.0"},
+		},
+		{
+			desc:     "unknown field",
+			hint:     "schema_url",
+			name:     "io.opentelemetry.example",
+			expected: model.LabelSet{},
+		},
+		{
+			desc: "no hint",
+			name: "io.opentelemetry.example",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			attrs := pcommon.NewMap()
+			if tC.hint != nil {
+				attrs.FromRaw(map[string]interface{}{hintScope: tC.hint})
+			}
+			scope := pcommon.NewInstrumentationScope()
+			scope.SetName(tC.name)
+			scope.SetVersion(tC.version)
+			assert.Equal(t, tC.expected, convertScopeToLabels(attrs, scope))
+		})
+	}
+}
+
 func TestRemoveAttributes(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -170,6 +326,9 @@ func TestRemoveAttributes(t *testing.T) {
 				hintResources:  "some.other.field",
 				hintFormat:     "logfmt",
 				hintTenant:     "some_tenant",
+				hintLineFields: "some.line.field",
+				hintSeverity:   "level",
+				hintTypes:      true,
 				"host.name":    "guarana",
 			},
 			labels: model.LabelSet{},
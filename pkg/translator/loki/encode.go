@@ -15,6 +15,7 @@
 package loki // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -35,32 +36,88 @@ type lokiEntry struct {
 	Resources  map[string]interface{} `json:"resources,omitempty"`
 }
 
+// lokiEntryFields are the names of the fields of a lokiEntry in the JSON line.
+var lokiEntryFields = map[string]struct{}{
+	"name":       {},
+	"body":       {},
+	"traceid":    {},
+	"spanid":     {},
+	"severity":   {},
+	"attributes": {},
+	"resources":  {},
+}
+
 // Encode converts an OTLP log record and its resource attributes into a JSON
 // string representing a Loki entry. An error is returned when the record can't
 // be marshaled into JSON.
 func Encode(lr plog.LogRecord, res pcommon.Resource) (string, error) {
+	return encodeJSON(lr, res, nil, false)
+}
+
+// encodeJSON works like Encode, but places the log attributes named in lineFields
+// at the root of the JSON line instead of under "attributes". Attributes whose
+// name collides with one of the fields of the Loki entry are left under "attributes".
+// When preserveTypes is true, doubles are written with a decimal point even when
+// their value is integral, so that e.g. the double 200 isn't read back as an int.
+func encodeJSON(lr plog.LogRecord, res pcommon.Resource, lineFields []string, preserveTypes bool) (string, error) {
 	var logRecord lokiEntry
 	var jsonRecord []byte
 	var err error
 	var body []byte
 
-	body, err = serializeBodyJSON(lr.Body())
+	body, err = serializeBodyJSON(lr.Body(), preserveTypes)
 	if err != nil {
 		return "", err
 	}
+
+	toRaw := pcommon.Map.AsRaw
+	if preserveTypes {
+		toRaw = typedMap
+	}
+
+	attributes := toRaw(lr.Attributes())
+	fields := map[string]interface{}{}
+	for _, name := range lineFields {
+		if _, reserved := lokiEntryFields[name]; reserved {
+			continue
+		}
+		if v, ok := attributes[name]; ok {
+			fields[name] = v
+			delete(attributes, name)
+		}
+	}
+
 	logRecord = lokiEntry{
 		Body:       body,
 		TraceID:    lr.TraceID().HexString(),
 		SpanID:     lr.SpanID().HexString(),
 		Severity:   lr.SeverityText(),
-		Attributes: lr.Attributes().AsRaw(),
-		Resources:  res.Attributes().AsRaw(),
+		Attributes: attributes,
+		Resources:  toRaw(res.Attributes()),
 	}
 
 	jsonRecord, err = json.Marshal(logRecord)
 	if err != nil {
 		return "", err
 	}
+	if len(fields) == 0 {
+		return string(jsonRecord), nil
+	}
+
+	// the fields of the entry are kept as raw messages so that their values aren't altered
+	line := map[string]json.RawMessage{}
+	if err = json.Unmarshal(jsonRecord, &line); err != nil {
+		return "", err
+	}
+	for k, v := range fields {
+		if line[k], err = json.Marshal(v); err != nil {
+			return "", err
+		}
+	}
+	jsonRecord, err = json.Marshal(line)
+	if err != nil {
+		return "", err
+	}
 	return string(jsonRecord), nil
 }
 
@@ -103,7 +160,29 @@ func EncodeLogfmt(lr plog.LogRecord, res pcommon.Resource) (string, error) {
 	return string(logfmtLine), nil
 }
 
-func serializeBodyJSON(body pcommon.Value) ([]byte, error) {
+// EncodeOTLP converts an OTLP log record, its instrumentation scope and its resource into
+// a JSON string representing them as OTLP logs, so that the complete log record can be
+// reconstructed from the Loki entry. An error is returned when the record can't be marshaled.
+func EncodeOTLP(lr plog.LogRecord, scope pcommon.InstrumentationScope, res pcommon.Resource) (string, error) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	res.CopyTo(rl.Resource())
+	sl := rl.ScopeLogs().AppendEmpty()
+	scope.CopyTo(sl.Scope())
+	lr.CopyTo(sl.LogRecords().AppendEmpty())
+
+	line, err := (&plog.JSONMarshaler{}).MarshalLogs(ld)
+	if err != nil {
+		return "", err
+	}
+	return string(line), nil
+}
+
+func serializeBodyJSON(body pcommon.Value, preserveTypes bool) ([]byte, error) {
+	if preserveTypes && body.Type() != pcommon.ValueTypeEmpty {
+		return json.Marshal(typedValue(body))
+	}
+
 	var str []byte
 	var err error
 	switch body.Type() {
@@ -137,6 +216,49 @@ func serializeBodyJSON(body pcommon.Value) ([]byte, error) {
 	return str, err
 }
 
+// jsonDouble is a double marshaled with a decimal point even when its value is integral,
+// so that it can be told apart from an int in the JSON line.
+type jsonDouble float64
+
+func (d jsonDouble) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(float64(d))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.ContainsAny(b, ".eE") {
+		b = append(b, ".0"...)
+	}
+	return b, nil
+}
+
+// typedMap works like pcommon.Map.AsRaw, but keeps the doubles, including the nested ones, as jsonDouble.
+func typedMap(m pcommon.Map) map[string]interface{} {
+	out := make(map[string]interface{}, m.Len())
+	m.Range(func(k string, v pcommon.Value) bool {
+		out[k] = typedValue(v)
+		return true
+	})
+	return out
+}
+
+func typedValue(v pcommon.Value) interface{} {
+	switch v.Type() {
+	case pcommon.ValueTypeDouble:
+		return jsonDouble(v.Double())
+	case pcommon.ValueTypeMap:
+		return typedMap(v.Map())
+	case pcommon.ValueTypeSlice:
+		s := v.Slice()
+		out := make([]interface{}, s.Len())
+		for i := 0; i < s.Len(); i++ {
+			out[i] = typedValue(s.At(i))
+		}
+		return out
+	default:
+		return v.AsRaw()
+	}
+}
+
 func bodyToKeyvals(body pcommon.Value) []interface{} {
 	switch body.Type() {
 	case pcommon.ValueTypeEmpty:
@@ -136,7 +136,7 @@ func TestSerializeComplexBody(t *testing.T) {
 	}
 
 	for _, test := range testcases {
-		out, err := serializeBodyJSON(test.input)
+		out, err := serializeBodyJSON(test.input, false)
 		assert.NoError(t, err)
 		assert.Equal(t, test.expectedJSON, out)
 
@@ -201,3 +201,66 @@ func TestEncodeLogfmtWithComplexAttributes(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, in, out)
 }
+
+func TestEncodeJsonWithLineFields(t *testing.T) {
+	in := `{"attr1":"1","attributes":{"attr2":"2","body":"b"},"body":"Example log","resources":{"host.name":"something"},"severity":"error","spanid":"0506070800000000","status":200,"traceid":"01020304000000000000000000000000"}`
+
+	log, resource := exampleLog()
+	log.Attributes().PutInt("status", 200)
+	log.Attributes().PutStr("body", "b")
+
+	out, err := encodeJSON(log, resource, []string{"attr1", "status", "body", "missing"}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, in, out)
+}
+
+func TestEncodeJsonWithPreservedTypes(t *testing.T) {
+	in := `{"body":{"duration":1.0},"traceid":"01020304000000000000000000000000","spanid":"0506070800000000","severity":"error","attributes":{"attr1":"1","attr2":"2","enabled":true,"ratio":200.0,"sizes":[1,2.5,3.0],"status":200},"resources":{"host.name":"something","load":1e+21}}`
+
+	log, resource := exampleLog()
+	log.Body().SetEmptyMap().PutDouble("duration", 1)
+	log.Attributes().PutInt("status", 200)
+	log.Attributes().PutDouble("ratio", 200)
+	log.Attributes().PutBool("enabled", true)
+	sizes := log.Attributes().PutEmptySlice("sizes")
+	sizes.AppendEmpty().SetInt(1)
+	sizes.AppendEmpty().SetDouble(2.5)
+	sizes.AppendEmpty().SetDouble(3)
+	resource.Attributes().PutDouble("load", 1e21)
+
+	out, err := encodeJSON(log, resource, nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	// without the hint, the integral doubles can't be told apart from the ints
+	out, err = encodeJSON(log, resource, nil, false)
+	assert.NoError(t, err)
+	assert.Contains(t, out, `"ratio":200,`)
+}
+
+func TestEncodeOTLP(t *testing.T) {
+	log, resource := exampleLog()
+	log.SetTimestamp(pcommon.Timestamp(1670000000000000000))
+	log.SetObservedTimestamp(pcommon.Timestamp(1670000000000000001))
+	log.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+	log.SetSeverityNumber(plog.SeverityNumberError)
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("io.opentelemetry.example")
+	scope.SetVersion("1.0.0")
+
+	out, err := EncodeOTLP(log, scope, resource)
+	assert.NoError(t, err)
+
+	// the complete log record can be reconstructed from the line
+	ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs([]byte(out))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ld.ResourceLogs().Len())
+	rl := ld.ResourceLogs().At(0)
+	assert.Equal(t, resource.Attributes().AsRaw(), rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, 1, rl.ScopeLogs().Len())
+	sl := rl.ScopeLogs().At(0)
+	assert.Equal(t, "io.opentelemetry.example", sl.Scope().Name())
+	assert.Equal(t, "1.0.0", sl.Scope().Version())
+	assert.Equal(t, 1, sl.LogRecords().Len())
+	assert.Equal(t, log, sl.LogRecords().At(0))
+}
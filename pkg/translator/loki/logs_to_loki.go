@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/grafana/loki/pkg/logproto"
+	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
@@ -25,6 +26,11 @@ import (
 type PushRequest struct {
 	*logproto.PushRequest
 	Report *PushReport
+
+	// StructuredMetadata holds the structured metadata of each entry, at the same indexes as the stream and the
+	// entry, so that entries with different structured metadata share a stream. It is nil when no log record
+	// has a "loki.metadata.labels" hint.
+	StructuredMetadata [][]model.LabelSet
 }
 
 // PushReport contains the summary for the outcome of a LogsToLoki operation
@@ -42,9 +48,16 @@ type PushReport struct {
 // and "loki.resource.labels". Each hint might contain a comma-separated list of
 // attributes (resource or record) that should be promoted to a Loki label. Those
 // attributes are removed from the body as a result, otherwise they would be shown
-// in duplicity in Loki.
+// in duplicity in Loki. When a resource and a log attribute promoted to labels have
+// the same name, the log attribute wins, unless the "loki.label.conflict" hint is
+// "resource". Similarly, the "loki.scope.labels" hint might list the
+// "name" and "version" of the instrumentation scope, promoted to the "scope_name"
+// and "scope_version" labels.
+// The "loki.metadata.labels" hint lists the log attributes that are moved to the
+// structured metadata of the entries instead. When the "loki.preserve.types" hint is
+// true, the doubles of a JSON line keep a decimal point so that they aren't mistaken for ints.
 // PushStreams are created based on the labels: all records containing the same
-// set of labels are part of the same stream. All streams are then packed within
+// set of labels are part of the same stream, whatever their structured metadata. All streams are then packed within
 // the resulting PushRequest.
 // When this function isn't able to marshal a log record, the log record is dropped
 // and processing continues, so that the caller can decide to either skip the entire
@@ -52,7 +65,7 @@ type PushReport struct {
 // to make this decision, as it includes all of the errors that were encountered,
 // as well as the number of items dropped and submitted.
 func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
-	groups := map[string]pushRequestGroup{}
+	groups := map[string]*pushRequestGroup{}
 
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
@@ -74,23 +87,30 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 				tenant := getTenantFromTenantHint(log.Attributes(), resource.Attributes())
 				group, ok := groups[tenant]
 				if !ok {
-					group = pushRequestGroup{
-						report:  &PushReport{},
-						streams: make(map[string]*logproto.Stream),
+					group = &pushRequestGroup{
+						report:   &PushReport{},
+						streams:  make(map[string]*logproto.Stream),
+						metadata: make(map[string][]model.LabelSet),
 					}
 					groups[tenant] = group
 				}
 
 				format := getFormatFromFormatHint(log.Attributes(), resource.Attributes())
+				lineFields := getLineFieldsFromHint(log.Attributes())
+				preserveTypes := getPreserveTypesFromHint(log.Attributes())
 
 				mergedLabels := convertAttributesAndMerge(log.Attributes(), resource.Attributes())
-				// remove the attributes that were promoted to labels
+				mergedLabels = mergedLabels.Merge(convertSeverityToLabel(log))
+				mergedLabels = mergedLabels.Merge(convertScopeToLabels(log.Attributes(), ills.At(j).Scope()))
+				metadata := convertAttributesToMetadata(log.Attributes())
+				// remove the attributes that were promoted to labels or moved to the structured metadata
 				removeAttributes(log.Attributes(), mergedLabels)
+				removeAttributes(log.Attributes(), metadata)
 				removeAttributes(resource.Attributes(), mergedLabels)
 
 				// create the stream name based on the labels
 				labels := mergedLabels.String()
-				entry, err := convertLogToLokiEntry(log, resource, format)
+				entry, err := convertLogToLokiEntry(log, ills.At(j).Scope(), resource, format, lineFields, preserveTypes)
 				if err != nil {
 					// Couldn't convert so dropping log.
 					group.report.Errors = append(group.report.Errors, fmt.Errorf("failed to convert, dropping log: %w", err))
@@ -100,6 +120,12 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 
 				group.report.NumSubmitted++
 
+				// the structured metadata is kept per entry, so that it doesn't split the streams
+				group.metadata[labels] = append(group.metadata[labels], metadata)
+				if len(metadata) > 0 {
+					group.hasMetadata = true
+				}
+
 				if stream, ok := group.streams[labels]; ok {
 					stream.Entries = append(stream.Entries, *entry)
 					continue
@@ -119,14 +145,23 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 			Streams: make([]logproto.Stream, len(g.streams)),
 		}
 
+		var metadata [][]model.LabelSet
+		if g.hasMetadata {
+			metadata = make([][]model.LabelSet, len(g.streams))
+		}
+
 		i := 0
-		for _, stream := range g.streams {
+		for key, stream := range g.streams {
 			pr.Streams[i] = *stream
+			if metadata != nil {
+				metadata[i] = g.metadata[key]
+			}
 			i++
 		}
 		requests[tenant] = PushRequest{
-			PushRequest: pr,
-			Report:      g.report,
+			PushRequest:        pr,
+			Report:             g.report,
+			StructuredMetadata: metadata,
 		}
 	}
 	return requests
@@ -170,6 +205,11 @@ func getTenantFromTenantHint(logAttr pcommon.Map, resourceAttr pcommon.Map) stri
 type pushRequestGroup struct {
 	streams map[string]*logproto.Stream
 	report  *PushReport
+
+	// metadata holds the structured metadata of the entries of each stream, by labels. hasMetadata
+	// is true when any of the entries has structured metadata.
+	metadata    map[string][]model.LabelSet
+	hasMetadata bool
 }
 
 // LogsToLoki converts a Logs pipeline data into a Loki PushRequest.
@@ -208,8 +248,12 @@ func LogsToLoki(ld plog.Logs) (*logproto.PushRequest, *PushReport) {
 				rls.At(i).Resource().CopyTo(resource)
 
 				format := getFormatFromFormatHint(log.Attributes(), resource.Attributes())
+				lineFields := getLineFieldsFromHint(log.Attributes())
+				preserveTypes := getPreserveTypesFromHint(log.Attributes())
 
 				mergedLabels := convertAttributesAndMerge(log.Attributes(), resource.Attributes())
+				mergedLabels = mergedLabels.Merge(convertSeverityToLabel(log))
+				mergedLabels = mergedLabels.Merge(convertScopeToLabels(log.Attributes(), ills.At(j).Scope()))
 				// remove the attributes that were promoted to labels
 				removeAttributes(log.Attributes(), mergedLabels)
 				removeAttributes(resource.Attributes(), mergedLabels)
@@ -217,7 +261,7 @@ func LogsToLoki(ld plog.Logs) (*logproto.PushRequest, *PushReport) {
 				// create the stream name based on the labels
 				labels := mergedLabels.String()
 
-				entry, err := convertLogToLokiEntry(log, resource, format)
+				entry, err := convertLogToLokiEntry(log, ills.At(j).Scope(), resource, format, lineFields, preserveTypes)
 				if err != nil {
 					// Couldn't convert so dropping log.
 					report.Errors = append(report.Errors, fmt.Errorf("failed to convert, dropping log: %w", err))
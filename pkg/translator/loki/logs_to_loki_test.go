@@ -19,7 +19,9 @@ import (
 	"testing"
 
 	"github.com/grafana/loki/pkg/logproto"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
@@ -300,6 +302,41 @@ func TestLogsToLokiRequestWithoutTenant(t *testing.T) {
 				`traceID=03000000000000000000000000000000 attribute_http.status=200`,
 			},
 		},
+		{
+			desc: "with otlp format",
+			attrs: map[string]interface{}{
+				"host.name":   "guarana",
+				"http.status": 200,
+			},
+			hints: map[string]interface{}{
+				hintAttributes: "host.name",
+				hintFormat:     formatOTLP,
+			},
+			expectedLabel: `{exporter="OTLP", host.name="guarana"}`,
+			expectedLines: []string{
+				`{"resourceLogs":[{"resource":{},"scopeLogs":[{"scope":{},"logRecords":[{"body":{},"attributes":[{"key":"http.status","value":{"intValue":"200"}}],"traceId":"01000000000000000000000000000000","spanId":""}]}]}]}`,
+				`{"resourceLogs":[{"resource":{},"scopeLogs":[{"scope":{},"logRecords":[{"body":{},"attributes":[{"key":"http.status","value":{"intValue":"200"}}],"traceId":"02000000000000000000000000000000","spanId":""}]}]}]}`,
+				`{"resourceLogs":[{"resource":{},"scopeLogs":[{"scope":{},"logRecords":[{"body":{},"attributes":[{"key":"http.status","value":{"intValue":"200"}}],"traceId":"03000000000000000000000000000000","spanId":""}]}]}]}`,
+			},
+		},
+		{
+			desc: "with attributes promoted to line fields",
+			attrs: map[string]interface{}{
+				"host.name":   "guarana",
+				"http.status": 200,
+				"http.method": "GET",
+			},
+			hints: map[string]interface{}{
+				hintAttributes: "host.name",
+				hintLineFields: "http.status, http.method",
+			},
+			expectedLabel: `{exporter="OTLP", host.name="guarana"}`,
+			expectedLines: []string{
+				`{"http.method":"GET","http.status":200,"traceid":"01000000000000000000000000000000"}`,
+				`{"http.method":"GET","http.status":200,"traceid":"02000000000000000000000000000000"}`,
+				`{"http.method":"GET","http.status":200,"traceid":"03000000000000000000000000000000"}`,
+			},
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -353,6 +390,89 @@ func TestLogsToLokiRequestWithoutTenant(t *testing.T) {
 	}
 }
 
+func TestLogsToLokiRequestWithScopeLabels(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	for _, name := range []string{"io.opentelemetry.first", "io.opentelemetry.second", ""} {
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(name)
+		sl.Scope().SetVersion("1.0.0")
+		for i := 0; i < 2; i++ {
+			lr := sl.LogRecords().AppendEmpty()
+			lr.Attributes().PutStr(hintScope, "name, version")
+			lr.Body().SetStr(name)
+		}
+	}
+
+	requests := LogsToLokiRequests(ld)
+	require.Len(t, requests, 1)
+
+	streams := map[string][]string{}
+	for _, stream := range requests[""].Streams {
+		for _, entry := range stream.Entries {
+			streams[stream.Labels] = append(streams[stream.Labels], entry.Line)
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		`{exporter="OTLP", scope_name="io.opentelemetry.first", scope_version="1.0.0"}`:  {`{"body":"io.opentelemetry.first"}`, `{"body":"io.opentelemetry.first"}`},
+		`{exporter="OTLP", scope_name="io.opentelemetry.second", scope_version="1.0.0"}`: {`{"body":"io.opentelemetry.second"}`, `{"body":"io.opentelemetry.second"}`},
+		`{exporter="OTLP", scope_version="1.0.0"}`:                                       {`{"body":""}`, `{"body":""}`},
+	}, streams)
+}
+
+func TestLogsToLokiRequestWithStructuredMetadata(t *testing.T) {
+	ld := plog.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, traceID := range []string{"1", "1", "2"} {
+		lr := logs.AppendEmpty()
+		lr.Attributes().PutStr(hintAttributes, "host.name")
+		lr.Attributes().PutStr(hintMetadata, "trace.id")
+		lr.Attributes().PutStr("host.name", "guarana")
+		lr.Attributes().PutStr("trace.id", traceID)
+		lr.Attributes().PutInt("http.status", 200)
+	}
+	lr := logs.AppendEmpty()
+	lr.Attributes().PutStr(hintAttributes, "host.name")
+	lr.Attributes().PutStr("host.name", "guarana")
+
+	requests := LogsToLokiRequests(ld)
+	require.Len(t, requests, 1)
+	request := requests[""]
+	// the entries share a stream whatever their structured metadata
+	require.Len(t, request.Streams, 1)
+	stream := request.Streams[0]
+	assert.Equal(t, `{exporter="OTLP", host.name="guarana"}`, stream.Labels)
+	for _, entry := range stream.Entries {
+		assert.NotContains(t, entry.Line, "trace.id")
+	}
+	assert.Equal(t, [][]model.LabelSet{{{"trace.id": "1"}, {"trace.id": "1"}, {"trace.id": "2"}, nil}}, request.StructuredMetadata)
+}
+
+func TestLogsToLokiRequestWithPreservedTypes(t *testing.T) {
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutBool(hintTypes, true)
+	lr.Attributes().PutInt("http.status", 200)
+	lr.Attributes().PutDouble("duration", 2)
+
+	requests := LogsToLokiRequests(ld)
+	require.Len(t, requests, 1)
+	request := requests[""]
+	require.Len(t, request.Streams, 1)
+	require.Len(t, request.Streams[0].Entries, 1)
+	assert.Equal(t, `{"attributes":{"duration":2.0,"http.status":200}}`, request.Streams[0].Entries[0].Line)
+}
+
+func TestLogsToLokiRequestWithoutStructuredMetadata(t *testing.T) {
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutStr("trace.id", "1")
+
+	requests := LogsToLokiRequests(ld)
+	require.Len(t, requests, 1)
+	assert.Nil(t, requests[""].StructuredMetadata)
+}
+
 func TestLogsToLoki(t *testing.T) {
 	testCases := []struct {
 		desc          string
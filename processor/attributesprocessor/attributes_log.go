@@ -23,6 +23,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterlog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 )
 
 type logAttributesProcessor struct {
@@ -54,9 +55,10 @@ func (a *logAttributesProcessor) processLogs(ctx context.Context, ld plog.Logs)
 			ils := ilss.At(j)
 			logs := ils.LogRecords()
 			library := ils.Scope()
+			schemaURLs := filtermatcher.SchemaURLs{Resource: rs.SchemaUrl(), Scope: ils.SchemaUrl()}
 			for k := 0; k < logs.Len(); k++ {
 				lr := logs.At(k)
-				if a.skipLog(lr, resource, library) {
+				if a.skipLog(lr, resource, library, schemaURLs) {
 					continue
 				}
 
@@ -73,17 +75,17 @@ func (a *logAttributesProcessor) processLogs(ctx context.Context, ld plog.Logs)
 // The logic determining if a log should be processed is set
 // in the attribute configuration with the include and exclude settings.
 // Include properties are checked before exclude settings are checked.
-func (a *logAttributesProcessor) skipLog(lr plog.LogRecord, resource pcommon.Resource, library pcommon.InstrumentationScope) bool {
+func (a *logAttributesProcessor) skipLog(lr plog.LogRecord, resource pcommon.Resource, library pcommon.InstrumentationScope, schemaURLs filtermatcher.SchemaURLs) bool {
 	if a.include != nil {
 		// A false returned in this case means the log should not be processed.
-		if include := a.include.MatchLogRecord(lr, resource, library); !include {
+		if include := a.include.MatchLogRecord(lr, resource, library, schemaURLs); !include {
 			return true
 		}
 	}
 
 	if a.exclude != nil {
 		// A true returned in this case means the log should not be processed.
-		if exclude := a.exclude.MatchLogRecord(lr, resource, library); exclude {
+		if exclude := a.exclude.MatchLogRecord(lr, resource, library, schemaURLs); exclude {
 			return true
 		}
 	}
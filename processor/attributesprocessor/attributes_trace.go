@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterspan"
 )
 
@@ -53,9 +54,10 @@ func (a *spanAttributesProcessor) processTraces(ctx context.Context, td ptrace.T
 			ils := ilss.At(j)
 			spans := ils.Spans()
 			library := ils.Scope()
+			schemaURLs := filtermatcher.SchemaURLs{Resource: rs.SchemaUrl(), Scope: ils.SchemaUrl()}
 			for k := 0; k < spans.Len(); k++ {
 				span := spans.At(k)
-				if filterspan.SkipSpan(a.include, a.exclude, span, resource, library) {
+				if filterspan.SkipSpan(a.include, a.exclude, span, resource, library, schemaURLs) {
 					continue
 				}
 
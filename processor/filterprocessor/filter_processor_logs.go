@@ -23,6 +23,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterlog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 )
 
 type filterLogProcessor struct {
@@ -82,19 +83,20 @@ func (flp *filterLogProcessor) filterLogRecords(rLogs plog.ResourceLogsSlice) {
 		for j := 0; j < scopes.Len(); j++ {
 			scope := scopes.At(j)
 			instrumentationScope := scope.Scope()
+			schemaURLs := filtermatcher.SchemaURLs{Resource: rLog.SchemaUrl(), Scope: scope.SchemaUrl()}
 			lrs := scope.LogRecords()
 
 			if flp.includeMatcher != nil {
 				// If includeMatcher exists, remove all records that do not match the filter.
 				lrs.RemoveIf(func(lr plog.LogRecord) bool {
-					return !flp.includeMatcher.MatchLogRecord(lr, resource, instrumentationScope)
+					return !flp.includeMatcher.MatchLogRecord(lr, resource, instrumentationScope, schemaURLs)
 				})
 			}
 
 			if flp.excludeMatcher != nil {
 				// If excludeMatcher exists, remove all records that match the filter.
 				lrs.RemoveIf(func(lr plog.LogRecord) bool {
-					return flp.excludeMatcher.MatchLogRecord(lr, resource, instrumentationScope)
+					return flp.excludeMatcher.MatchLogRecord(lr, resource, instrumentationScope, schemaURLs)
 				})
 			}
 		}
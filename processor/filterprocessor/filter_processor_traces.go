@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterspan"
 )
 
@@ -91,8 +92,9 @@ func (fsp *filterSpanProcessor) processTraces(_ context.Context, pdt ptrace.Trac
 		resSpan := pdt.ResourceSpans().At(i)
 		for x := 0; x < resSpan.ScopeSpans().Len(); x++ {
 			ils := resSpan.ScopeSpans().At(x)
+			schemaURLs := filtermatcher.SchemaURLs{Resource: resSpan.SchemaUrl(), Scope: ils.SchemaUrl()}
 			ils.Spans().RemoveIf(func(span ptrace.Span) bool {
-				return fsp.shouldRemoveSpan(span, resSpan.Resource(), ils.Scope())
+				return fsp.shouldRemoveSpan(span, resSpan.Resource(), ils.Scope(), schemaURLs)
 			})
 		}
 		// Remove empty elements, that way if we delete everything we can tell
@@ -110,15 +112,15 @@ func (fsp *filterSpanProcessor) processTraces(_ context.Context, pdt ptrace.Trac
 	return pdt, nil
 }
 
-func (fsp *filterSpanProcessor) shouldRemoveSpan(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope) bool {
+func (fsp *filterSpanProcessor) shouldRemoveSpan(span ptrace.Span, resource pcommon.Resource, library pcommon.InstrumentationScope, schemaURLs filtermatcher.SchemaURLs) bool {
 	if fsp.include != nil {
-		if !fsp.include.MatchSpan(span, resource, library) {
+		if !fsp.include.MatchSpan(span, resource, library, schemaURLs) {
 			return true
 		}
 	}
 
 	if fsp.exclude != nil {
-		if fsp.exclude.MatchSpan(span, resource, library) {
+		if fsp.exclude.MatchSpan(span, resource, library, schemaURLs) {
 			return true
 		}
 	}
@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterspan"
 )
 
@@ -91,9 +92,10 @@ func (sp *spanProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptr
 			ils := ilss.At(j)
 			spans := ils.Spans()
 			library := ils.Scope()
+			schemaURLs := filtermatcher.SchemaURLs{Resource: rs.SchemaUrl(), Scope: ils.SchemaUrl()}
 			for k := 0; k < spans.Len(); k++ {
 				s := spans.At(k)
-				if filterspan.SkipSpan(sp.include, sp.exclude, s, resource, library) {
+				if filterspan.SkipSpan(sp.include, sp.exclude, s, resource, library, schemaURLs) {
 					continue
 				}
 				sp.processFromAttributes(s)
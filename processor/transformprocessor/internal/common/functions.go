@@ -26,6 +26,42 @@ func Functions[K any]() map[string]interface{} {
 		"Concat":               ottlfuncs.Concat[K],
 		"Split":                ottlfuncs.Split[K],
 		"Int":                  ottlfuncs.Int[K],
+		"ParseUserAgent":       ottlfuncs.ParseUserAgent[K],
+		"Ceil":                 ottlfuncs.Ceil[K],
+		"Floor":                ottlfuncs.Floor[K],
+		"FingerprintHash":      ottlfuncs.FingerprintHash[K],
+		"Multiply":             ottlfuncs.Multiply[K],
+		"Add":                  ottlfuncs.Add[K],
+		"IsIPAddress":          ottlfuncs.IsIPAddress[K],
+		"ToJSON":               ottlfuncs.ToJSON[K],
+		"SimilarityRatio":      ottlfuncs.SimilarityRatio[K],
+		"DistinctCount":        ottlfuncs.DistinctCount[K],
+		"ParseQueryString":     ottlfuncs.ParseQueryString[K],
+		"Field":                ottlfuncs.Field[K],
+		"IsDivisibleBy":        ottlfuncs.IsDivisibleBy[K],
+		"StripANSI":            ottlfuncs.StripANSI[K],
+		"ParseSyslogPriority":  ottlfuncs.ParseSyslogPriority[K],
+		"Sum":                  ottlfuncs.Sum[K],
+		"DecodeJWT":            ottlfuncs.DecodeJWT[K],
+		"StdDev":               ottlfuncs.StdDev[K],
+		"Contains":             ottlfuncs.Contains[K],
+		"SampleDecision":       ottlfuncs.SampleDecision[K],
+		"NormalizeUnicode":     ottlfuncs.NormalizeUnicode[K],
+		"TruncateTime":         ottlfuncs.TruncateTime[K],
+		"GetQueryParam":        ottlfuncs.GetQueryParam[K],
+		"Since":                ottlfuncs.Since[K],
+		"EqualsIgnoreCase":     ottlfuncs.EqualsIgnoreCase[K],
+		"GCD":                  ottlfuncs.GCD[K],
+		"HexDump":              ottlfuncs.HexDump[K],
+		"TimeBucket":           ottlfuncs.TimeBucket[K],
+		"IndexOf":              ottlfuncs.IndexOf[K],
+		"Mask":                 ottlfuncs.Mask[K],
+		"ParseFixedWidth":      ottlfuncs.ParseFixedWidth[K],
+		"BitAnd":               ottlfuncs.BitAnd[K],
+		"BitOr":                ottlfuncs.BitOr[K],
+		"BitXor":               ottlfuncs.BitXor[K],
+		"HashRing":             ottlfuncs.HashRing[K],
+		"IsSubsetOf":           ottlfuncs.IsSubsetOf[K],
 		"keep_keys":            ottlfuncs.KeepKeys[K],
 		"set":                  ottlfuncs.Set[K],
 		"truncate_all":         ottlfuncs.TruncateAll[K],
@@ -36,5 +72,7 @@ func Functions[K any]() map[string]interface{} {
 		"replace_all_patterns": ottlfuncs.ReplaceAllPatterns[K],
 		"delete_key":           ottlfuncs.DeleteKey[K],
 		"delete_matching_keys": ottlfuncs.DeleteMatchingKeys[K],
+		"rename_key":           ottlfuncs.RenameKey[K],
+		"replace_first":        ottlfuncs.ReplaceFirst[K],
 	}
 }
@@ -193,6 +193,15 @@ func TestProcess(t *testing.T) {
 			statement: `set(attributes["test"], Split(attributes["not_exist"], "|"))`,
 			want:      func(td plog.Logs) {},
 		},
+		{
+			statement: `set(attributes["test"], ParseUserAgent("Mozilla/5.0 (X11; Linux x86_64; rv:106.0) Gecko/20100101 Firefox/106.0")) where body == "operationA"`,
+			want: func(td plog.Logs) {
+				newValue := td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutEmptyMap("test")
+				newValue.PutStr("browser", "Firefox")
+				newValue.PutStr("os", "Linux")
+				newValue.PutStr("device", "Desktop")
+			},
+		},
 	}
 
 	for _, tt := range tests {
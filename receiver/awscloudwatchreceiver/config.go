@@ -29,6 +29,8 @@ var (
 	defaultPollInterval  = time.Minute
 	defaultEventLimit    = 1000
 	defaultLogGroupLimit = 50
+	// defaultMaxConcurrentPolls keeps polling log groups sequentially unless configured otherwise.
+	defaultMaxConcurrentPolls = 1
 )
 
 // Config is the overall config structure for the awscloudwatchreceiver
@@ -44,7 +46,11 @@ type Config struct {
 type LogsConfig struct {
 	PollInterval        time.Duration `mapstructure:"poll_interval"`
 	MaxEventsPerRequest int           `mapstructure:"max_events_per_request"`
-	Groups              GroupConfig   `mapstructure:"groups"`
+	MaxConcurrentPolls  int           `mapstructure:"max_concurrent_polls"`
+	// BatchEvents groups all the events of a FilterLogEvents response under a single resource,
+	// instead of creating a resource per event.
+	BatchEvents bool        `mapstructure:"batch_events"`
+	Groups      GroupConfig `mapstructure:"groups"`
 }
 
 // GroupConfig is the configuration for log group collection
@@ -71,6 +77,7 @@ var (
 	errNoLogsConfigured               = errors.New("no logs configured")
 	errInvalidEventLimit              = errors.New("event limit is improperly configured, value must be greater than 0")
 	errInvalidPollInterval            = errors.New("poll interval is incorrect, it must be a duration greater than one second")
+	errInvalidMaxConcurrentPolls      = errors.New("max concurrent polls is improperly configured, value must be greater than 0")
 	errInvalidAutodiscoverLimit       = errors.New("the limit of autodiscovery of log groups is improperly configured, value must be greater than 0")
 	errAutodiscoverAndNamedConfigured = errors.New("both autodiscover and named configs are configured, Only one or the other is permitted")
 )
@@ -122,6 +129,9 @@ func (c *Config) validateLogsConfig() error {
 	if c.Logs.PollInterval < time.Second {
 		return errInvalidPollInterval
 	}
+	if c.Logs.MaxConcurrentPolls <= 0 {
+		return errInvalidMaxConcurrentPolls
+	}
 
 	return c.Logs.Groups.validate()
 }
@@ -38,6 +38,7 @@ func TestValidate(t *testing.T) {
 				Region: "us-west-2",
 				Logs: &LogsConfig{
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 					PollInterval:        defaultPollInterval,
 					Groups: GroupConfig{
 						AutodiscoverConfig: nil,
@@ -77,6 +78,7 @@ func TestValidate(t *testing.T) {
 				Region: "us-west-2",
 				Logs: &LogsConfig{
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 					PollInterval:        100 * time.Millisecond,
 					Groups: GroupConfig{
 						AutodiscoverConfig: nil,
@@ -85,12 +87,25 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: errInvalidPollInterval,
 		},
+		{
+			name: "Invalid Max Concurrent Polls",
+			config: Config{
+				Region: "us-west-2",
+				Logs: &LogsConfig{
+					MaxEventsPerRequest: defaultEventLimit,
+					PollInterval:        defaultPollInterval,
+					MaxConcurrentPolls:  0,
+				},
+			},
+			expectedErr: errInvalidMaxConcurrentPolls,
+		},
 		{
 			name: "Invalid Log Group Limit",
 			config: Config{
 				Region: "us-east-1",
 				Logs: &LogsConfig{
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 					PollInterval:        defaultPollInterval,
 					Groups: GroupConfig{
 						AutodiscoverConfig: &AutodiscoverConfig{
@@ -107,6 +122,7 @@ func TestValidate(t *testing.T) {
 				IMDSEndpoint: "xyz",
 				Logs: &LogsConfig{
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 					PollInterval:        defaultPollInterval,
 					Groups: GroupConfig{
 						AutodiscoverConfig: nil,
@@ -121,6 +137,7 @@ func TestValidate(t *testing.T) {
 				Region: "us-east-1",
 				Logs: &LogsConfig{
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 					PollInterval:        defaultPollInterval,
 					Groups: GroupConfig{
 						AutodiscoverConfig: &AutodiscoverConfig{
@@ -166,6 +183,7 @@ func TestLoadConfig(t *testing.T) {
 				Logs: &LogsConfig{
 					PollInterval:        time.Minute,
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 					Groups: GroupConfig{
 						AutodiscoverConfig: &AutodiscoverConfig{
 							Limit: defaultLogGroupLimit,
@@ -182,6 +200,8 @@ func TestLoadConfig(t *testing.T) {
 				Logs: &LogsConfig{
 					PollInterval:        time.Minute,
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  4,
+					BatchEvents:         true,
 					Groups: GroupConfig{
 						AutodiscoverConfig: &AutodiscoverConfig{
 							Limit:  100,
@@ -199,6 +219,7 @@ func TestLoadConfig(t *testing.T) {
 				Logs: &LogsConfig{
 					PollInterval:        time.Minute,
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 					Groups: GroupConfig{
 						AutodiscoverConfig: &AutodiscoverConfig{
 							Limit: 100,
@@ -218,6 +239,7 @@ func TestLoadConfig(t *testing.T) {
 				Logs: &LogsConfig{
 					PollInterval:        time.Minute,
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 					Groups: GroupConfig{
 						AutodiscoverConfig: &AutodiscoverConfig{
 							Limit: 100,
@@ -238,6 +260,7 @@ func TestLoadConfig(t *testing.T) {
 				Logs: &LogsConfig{
 					PollInterval:        5 * time.Minute,
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 					Groups: GroupConfig{
 						NamedConfigs: map[string]StreamConfig{
 							"/aws/eks/dev-0/cluster": {},
@@ -255,6 +278,7 @@ func TestLoadConfig(t *testing.T) {
 				Logs: &LogsConfig{
 					PollInterval:        5 * time.Minute,
 					MaxEventsPerRequest: defaultEventLimit,
+					MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 					Groups: GroupConfig{
 						NamedConfigs: map[string]StreamConfig{
 							"/aws/eks/dev-0/cluster": {
@@ -53,6 +53,7 @@ func createDefaultConfig() config.Receiver {
 		Logs: &LogsConfig{
 			PollInterval:        defaultPollInterval,
 			MaxEventsPerRequest: defaultEventLimit,
+			MaxConcurrentPolls:  defaultMaxConcurrentPolls,
 			Groups: GroupConfig{
 				AutodiscoverConfig: &AutodiscoverConfig{
 					Limit: defaultLogGroupLimit,
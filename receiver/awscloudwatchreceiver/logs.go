@@ -38,6 +38,8 @@ type logsReceiver struct {
 	imdsEndpoint        string
 	pollInterval        time.Duration
 	maxEventsPerRequest int
+	maxConcurrentPolls  int
+	batchEvents         bool
 	nextStartTime       time.Time
 	groupRequests       []groupRequest
 	autodiscover        *AutodiscoverConfig
@@ -126,6 +128,8 @@ func newLogsReceiver(cfg *Config, logger *zap.Logger, consumer consumer.Logs) *l
 		profile:             cfg.Profile,
 		consumer:            consumer,
 		maxEventsPerRequest: cfg.Logs.MaxEventsPerRequest,
+		maxConcurrentPolls:  cfg.Logs.MaxConcurrentPolls,
+		batchEvents:         cfg.Logs.BatchEvents,
 		imdsEndpoint:        cfg.IMDSEndpoint,
 		autodiscover:        autodiscover,
 		pollInterval:        cfg.Logs.PollInterval,
@@ -179,15 +183,38 @@ func (l *logsReceiver) startPolling(ctx context.Context) {
 	}
 }
 
+// poll polls every group request, running at most maxConcurrentPolls of them at the same time.
+// An error polling one group doesn't prevent the others from being polled.
 func (l *logsReceiver) poll(ctx context.Context) error {
-	var errs error
+	// the session is shared by all the group requests, so it is established before polling concurrently
+	if err := l.ensureSession(); err != nil {
+		return err
+	}
+
+	var (
+		errs    error
+		errsMux sync.Mutex
+		wg      sync.WaitGroup
+	)
 	startTime := l.nextStartTime
 	endTime := time.Now()
+	sem := make(chan struct{}, l.maxConcurrentPolls)
 	for _, r := range l.groupRequests {
-		if err := l.pollForLogs(ctx, r, startTime, endTime); err != nil {
-			errs = multierr.Append(errs, err)
-		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(r groupRequest) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := l.pollForLogs(ctx, r, startTime, endTime); err != nil {
+				errsMux.Lock()
+				errs = multierr.Append(errs, err)
+				errsMux.Unlock()
+			}
+		}(r)
 	}
+	wg.Wait()
 	l.nextStartTime = endTime
 	return errs
 }
@@ -210,15 +237,13 @@ func (l *logsReceiver) pollForLogs(ctx context.Context, pc groupRequest, startTi
 			input := pc.request(l.maxEventsPerRequest, *nextToken, &startTime, &endTime)
 			resp, err := l.client.FilterLogEventsWithContext(ctx, input)
 			if err != nil {
-				l.logger.Error("unable to retrieve logs from cloudwatch", zap.String("log group", pc.groupName()), zap.Error(err))
-				break
+				return fmt.Errorf("unable to retrieve logs from log group %q: %w", pc.groupName(), err)
 			}
 			observedTime := pcommon.NewTimestampFromTime(time.Now())
 			logs := l.processEvents(observedTime, pc.groupName(), resp)
 			if logs.LogRecordCount() > 0 {
 				if err = l.consumer.ConsumeLogs(ctx, logs); err != nil {
-					l.logger.Error("unable to consume logs", zap.Error(err))
-					break
+					return fmt.Errorf("unable to consume logs from log group %q: %w", pc.groupName(), err)
 				}
 			}
 			nextToken = resp.NextToken
@@ -245,20 +270,39 @@ func (l *logsReceiver) processEvents(now pcommon.Timestamp, logGroupName string,
 			continue
 		}
 
-		rl := logs.ResourceLogs().AppendEmpty()
-		resourceAttributes := rl.Resource().Attributes()
-		resourceAttributes.PutStr("aws.region", l.region)
-		resourceAttributes.PutStr("cloudwatch.log.group.name", logGroupName)
-		if e.LogStreamName != nil {
-			resourceAttributes.PutStr("cloudwatch.log.stream", *e.LogStreamName)
+		var logRecord plog.LogRecord
+		if l.batchEvents {
+			// every event of the response shares the resource of the log group,
+			// so the log stream is recorded on the log record instead
+			if logs.ResourceLogs().Len() == 0 {
+				rl := logs.ResourceLogs().AppendEmpty()
+				resourceAttributes := rl.Resource().Attributes()
+				resourceAttributes.PutStr("aws.region", l.region)
+				resourceAttributes.PutStr("cloudwatch.log.group.name", logGroupName)
+				rl.ScopeLogs().AppendEmpty().LogRecords().EnsureCapacity(len(output.Events))
+			}
+			logRecord = logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty()
+			if e.LogStreamName != nil {
+				logRecord.Attributes().PutStr("cloudwatch.log.stream", *e.LogStreamName)
+			}
+		} else {
+			rl := logs.ResourceLogs().AppendEmpty()
+			resourceAttributes := rl.Resource().Attributes()
+			resourceAttributes.PutStr("aws.region", l.region)
+			resourceAttributes.PutStr("cloudwatch.log.group.name", logGroupName)
+			if e.LogStreamName != nil {
+				resourceAttributes.PutStr("cloudwatch.log.stream", *e.LogStreamName)
+			}
+			logRecord = rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
 		}
 
-		logRecord := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
 		logRecord.SetObservedTimestamp(now)
 		ts := time.UnixMilli(*e.Timestamp)
 		logRecord.SetTimestamp(pcommon.NewTimestampFromTime(ts))
 		logRecord.Body().SetStr(*e.Message)
 		logRecord.Attributes().PutStr("id", *e.EventId)
+		// the size of the raw event, which CloudWatch bills by, for cost tracking
+		logRecord.Attributes().PutInt("aws.cloudwatch.event.bytes", int64(len(*e.Message)))
 	}
 	return logs
 }
@@ -16,11 +16,13 @@ package awscloudwatchreceiver // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 )
@@ -176,6 +179,136 @@ func TestShutdownWhileCollecting(t *testing.T) {
 	require.NoError(t, alertRcvr.Shutdown(context.Background()))
 }
 
+func TestConcurrentPolling(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Region = "us-west-1"
+	cfg.Logs.MaxConcurrentPolls = 2
+	cfg.Logs.Groups = GroupConfig{
+		NamedConfigs: map[string]StreamConfig{
+			"group-1":       {},
+			"group-2":       {},
+			"group-3":       {},
+			"group-4":       {},
+			"group-5":       {},
+			"failing-group": {},
+		},
+	}
+
+	sink := &consumertest.LogsSink{}
+	logsRcvr := newLogsReceiver(cfg, zap.NewNop(), sink)
+	mc := &concurrencyClient{
+		delay:        100 * time.Millisecond,
+		failingGroup: "failing-group",
+	}
+	logsRcvr.client = mc
+
+	err := logsRcvr.poll(context.Background())
+	require.ErrorContains(t, err, "failing-group")
+
+	// the failing group doesn't prevent the other groups from being polled
+	require.Equal(t, 5, sink.LogRecordCount())
+	require.Equal(t, int32(2), atomic.LoadInt32(&mc.maxInFlight))
+}
+
+func TestEventBytes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Region = "us-west-1"
+
+	logsRcvr := newLogsReceiver(cfg, zap.NewNop(), &consumertest.LogsSink{})
+	output := &cloudwatchlogs.FilterLogEventsOutput{
+		Events: []*cloudwatchlogs.FilteredLogEvent{
+			{
+				EventId:   aws.String("1"),
+				Message:   aws.String(""),
+				Timestamp: aws.Int64(testTimeStamp),
+			},
+			{
+				EventId:   aws.String("2"),
+				Message:   aws.String("hello"),
+				Timestamp: aws.Int64(testTimeStamp),
+			},
+			{
+				// the size is in bytes rather than characters
+				EventId:   aws.String("3"),
+				Message:   aws.String("héllo 🚀"),
+				Timestamp: aws.Int64(testTimeStamp),
+			},
+		},
+	}
+
+	logs := logsRcvr.processEvents(pcommon.NewTimestampFromTime(time.Now()), testLogGroupName, output)
+
+	require.Equal(t, 3, logs.ResourceLogs().Len())
+	for i, expected := range []int64{0, 5, 11} {
+		logRecord := logs.ResourceLogs().At(i).ScopeLogs().At(0).LogRecords().At(0)
+		size, ok := logRecord.Attributes().Get("aws.cloudwatch.event.bytes")
+		require.True(t, ok)
+		require.Equal(t, expected, size.Int())
+		require.Equal(t, int64(len(logRecord.Body().Str())), size.Int())
+	}
+}
+
+func TestBatchEvents(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Region = "us-west-1"
+	cfg.Logs.BatchEvents = true
+
+	logsRcvr := newLogsReceiver(cfg, zap.NewNop(), &consumertest.LogsSink{})
+	output := &cloudwatchlogs.FilterLogEventsOutput{
+		Events: []*cloudwatchlogs.FilteredLogEvent{
+			{
+				EventId:       aws.String("1"),
+				LogStreamName: aws.String("stream-1"),
+				Message:       aws.String("first"),
+				Timestamp:     aws.Int64(testTimeStamp),
+			},
+			{
+				EventId:       aws.String("2"),
+				LogStreamName: aws.String("stream-2"),
+				Message:       aws.String("second"),
+				Timestamp:     aws.Int64(testTimeStamp),
+			},
+			{
+				// skipped as the event has no message
+				EventId:       aws.String("3"),
+				LogStreamName: aws.String("stream-1"),
+				Timestamp:     aws.Int64(testTimeStamp),
+			},
+			{
+				EventId:   aws.String("4"),
+				Message:   aws.String("third"),
+				Timestamp: aws.Int64(testTimeStamp),
+			},
+		},
+	}
+
+	logs := logsRcvr.processEvents(pcommon.NewTimestampFromTime(time.Now()), testLogGroupName, output)
+
+	require.Equal(t, 1, logs.ResourceLogs().Len())
+	rl := logs.ResourceLogs().At(0)
+	require.Equal(t, map[string]interface{}{
+		"aws.region":                "us-west-1",
+		"cloudwatch.log.group.name": testLogGroupName,
+	}, rl.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rl.ScopeLogs().Len())
+
+	logRecords := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, logRecords.Len())
+	expected := []map[string]interface{}{
+		{"id": "1", "cloudwatch.log.stream": "stream-1", "aws.cloudwatch.event.bytes": int64(5)},
+		{"id": "2", "cloudwatch.log.stream": "stream-2", "aws.cloudwatch.event.bytes": int64(6)},
+		{"id": "4", "aws.cloudwatch.event.bytes": int64(5)},
+	}
+	for i, attrs := range expected {
+		require.Equal(t, attrs, logRecords.At(i).Attributes().AsRaw())
+	}
+	require.Equal(t, "third", logRecords.At(2).Body().Str())
+
+	// a response without valid events doesn't produce an empty resource
+	logs = logsRcvr.processEvents(pcommon.NewTimestampFromTime(time.Now()), testLogGroupName, &cloudwatchlogs.FilterLogEventsOutput{})
+	require.Equal(t, 0, logs.ResourceLogs().Len())
+}
+
 func defaultMockClient() client {
 	mc := &mockClient{}
 	mc.On("DescribeLogGroupsWithContext", mock.Anything, mock.Anything, mock.Anything).Return(
@@ -371,6 +504,43 @@ func (mc *mockClient) FilterLogEventsWithContext(ctx context.Context, input *clo
 	return args.Get(0).(*cloudwatchlogs.FilterLogEventsOutput), args.Error(1)
 }
 
+// concurrencyClient is a client keeping track of the maximum number of concurrent requests.
+type concurrencyClient struct {
+	delay        time.Duration
+	failingGroup string
+	inFlight     int32
+	maxInFlight  int32
+}
+
+func (cc *concurrencyClient) DescribeLogGroupsWithContext(context.Context, *cloudwatchlogs.DescribeLogGroupsInput, ...request.Option) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	return &cloudwatchlogs.DescribeLogGroupsOutput{}, nil
+}
+
+func (cc *concurrencyClient) FilterLogEventsWithContext(_ context.Context, input *cloudwatchlogs.FilterLogEventsInput, _ ...request.Option) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	n := atomic.AddInt32(&cc.inFlight, 1)
+	defer atomic.AddInt32(&cc.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&cc.maxInFlight)
+		if n <= max || atomic.CompareAndSwapInt32(&cc.maxInFlight, max, n) {
+			break
+		}
+	}
+	time.Sleep(cc.delay)
+
+	if *input.LogGroupName == cc.failingGroup {
+		return nil, errors.New("throttled")
+	}
+	return &cloudwatchlogs.FilterLogEventsOutput{
+		Events: []*cloudwatchlogs.FilteredLogEvent{
+			{
+				EventId:   &testEventID,
+				Message:   aws.String(testLogStreamMessage),
+				Timestamp: aws.Int64(testTimeStamp),
+			},
+		},
+	}, nil
+}
+
 func readLogs(path string) (plog.Logs, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/loadscraper"
 )
 
 const (
@@ -44,6 +45,15 @@ func (cfg *Config) Validate() error {
 		return errors.New("must specify at least one scraper when using hostmetrics receiver")
 	}
 
+	if loadCfg, ok := cfg.Scrapers[loadscraper.TypeStr].(*loadscraper.Config); ok {
+		if err := loadCfg.Validate(); err != nil {
+			return fmt.Errorf("invalid load scraper configuration: %w", err)
+		}
+		if err := loadCfg.ValidateSamplingInterval(cfg.CollectionInterval); err != nil {
+			return fmt.Errorf("invalid load scraper configuration: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -100,6 +100,22 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, expectedConfig, r1)
 }
 
+func TestLoadConfig_CPUAverageWindows(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config-cpuaveragewindows.yaml"), factories)
+	require.NoError(t, err)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)].(*Config)
+	expectedLoadConfig := (&loadscraper.Factory{}).CreateDefaultConfig().(*loadscraper.Config)
+	expectedLoadConfig.CPUAverageWindows = []string{"5m", "15m"}
+	expectedLoadConfig.CPUCountSource = "runtime"
+	assert.Equal(t, expectedLoadConfig, r0.Scrapers[loadscraper.TypeStr])
+}
+
 func TestLoadInvalidConfig_NoScrapers(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	require.NoError(t, err)
@@ -121,3 +137,36 @@ func TestLoadInvalidConfig_InvalidScraperKey(t *testing.T) {
 
 	require.Contains(t, err.Error(), "error reading receivers configuration for \"hostmetrics\": invalid scraper key: invalidscraperkey")
 }
+
+func TestLoadInvalidConfig_SamplingInterval(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	_, err = servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config-invalidsamplinginterval.yaml"), factories)
+
+	require.Contains(t, err.Error(), "receiver \"hostmetrics\" has invalid configuration: invalid load scraper configuration: \"sampling_interval\" (30s) must not be larger than the collection interval (10s)")
+}
+
+func TestLoadInvalidConfig_CPUAverageWindows(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	_, err = servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config-invalidcpuaveragewindows.yaml"), factories)
+
+	require.Contains(t, err.Error(), "receiver \"hostmetrics\" has invalid configuration: invalid load scraper configuration: invalid cpu_average_windows entry \"10m\", must be one of 1m, 5m or 15m")
+}
+
+func TestLoadInvalidConfig_CPUCountSource(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	_, err = servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config-invalidcpucountsource.yaml"), factories)
+
+	require.Contains(t, err.Error(), "receiver \"hostmetrics\" has invalid configuration: invalid load scraper configuration: invalid cpu_count_source \"cgroup\", must be one of logical or runtime")
+}
@@ -15,13 +15,110 @@
 package loadscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/loadscraper"
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/loadscraper/internal/metadata"
 )
 
 // Config relating to Load Metric Scraper.
 type Config struct {
 	// If true, metrics will be average load per cpu
+	// Deprecated: [v0.64.0] use CPUAverageWindows with all of "1m", "5m" and "15m" instead.
 	CPUAverage bool `mapstructure:"cpu_average"`
+	// CPUAverageWindows lists the load averages, among "1m", "5m" and "15m", that are divided by the number of
+	// logical CPUs, the other ones being left as is.
+	CPUAverageWindows []string `mapstructure:"cpu_average_windows"`
+	// CPUCountSource defines how the number of logical CPUs is obtained, either "logical" (default), counting the
+	// CPUs reported by the host on each scrape, or "runtime", counting the CPUs usable by the collector process
+	// when it started.
+	CPUCountSource string `mapstructure:"cpu_count_source"`
+	// WindowsEmulateLoad indicates whether, on Windows, which has no load averages, they are emulated from
+	// the samples of the "System\Processor Queue Length" performance counter. When false, no load metrics
+	// are scraped on Windows. It is ignored on the other platforms.
+	WindowsEmulateLoad bool `mapstructure:"windows_emulate_load"`
+	// SamplingInterval is the interval at which the processor queue length is sampled to emulate the load
+	// averages on Windows, trading accuracy against overhead. It must not be larger than the collection
+	// interval. Defaults to 5s when unset.
+	SamplingInterval time.Duration `mapstructure:"sampling_interval"`
 	// Metrics allows to customize scraped metrics representation.
 	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
 }
+
+// defaultSamplingInterval is the interval at which the load is sampled when SamplingInterval is unset.
+const defaultSamplingInterval = 5 * time.Second
+
+const (
+	cpuCountSourceLogical = "logical"
+	cpuCountSourceRuntime = "runtime"
+)
+
+// averageWindows defines which of the load averages are divided by the number of logical CPUs.
+type averageWindows struct {
+	load1  bool
+	load5  bool
+	load15 bool
+}
+
+// averageWindows returns the load averages to divide by the number of logical CPUs, all of them if the
+// deprecated CPUAverage is set.
+func (cfg *Config) averageWindows() (averageWindows, error) {
+	if cfg.CPUAverage {
+		return averageWindows{load1: true, load5: true, load15: true}, nil
+	}
+	var windows averageWindows
+	for _, window := range cfg.CPUAverageWindows {
+		switch window {
+		case "1m":
+			windows.load1 = true
+		case "5m":
+			windows.load5 = true
+		case "15m":
+			windows.load15 = true
+		default:
+			return averageWindows{}, fmt.Errorf("invalid cpu_average_windows entry %q, must be one of 1m, 5m or 15m", window)
+		}
+	}
+	return windows, nil
+}
+
+// cpuCountSource returns how the number of logical CPUs is obtained.
+func (cfg *Config) cpuCountSource() (string, error) {
+	switch cfg.CPUCountSource {
+	case "", cpuCountSourceLogical:
+		return cpuCountSourceLogical, nil
+	case cpuCountSourceRuntime:
+		return cpuCountSourceRuntime, nil
+	}
+	return "", fmt.Errorf("invalid cpu_count_source %q, must be one of %s or %s", cfg.CPUCountSource, cpuCountSourceLogical, cpuCountSourceRuntime)
+}
+
+// Validate checks that the entries of cpu_average_windows and the cpu_count_source are supported.
+func (cfg *Config) Validate() error {
+	if _, err := cfg.averageWindows(); err != nil {
+		return err
+	}
+	_, err := cfg.cpuCountSource()
+	return err
+}
+
+// ValidateSamplingInterval checks that the sampling interval, if set, is positive and not larger than the
+// collection interval of the receiver.
+func (cfg *Config) ValidateSamplingInterval(collectionInterval time.Duration) error {
+	if cfg.SamplingInterval < 0 {
+		return errors.New("\"sampling_interval\" must be positive")
+	}
+	if cfg.SamplingInterval > collectionInterval {
+		return fmt.Errorf("\"sampling_interval\" (%v) must not be larger than the collection interval (%v)", cfg.SamplingInterval, collectionInterval)
+	}
+	return nil
+}
+
+// samplingInterval returns the interval at which the load is sampled.
+func (cfg *Config) samplingInterval() time.Duration {
+	if cfg.SamplingInterval > 0 {
+		return cfg.SamplingInterval
+	}
+	return defaultSamplingInterval
+}
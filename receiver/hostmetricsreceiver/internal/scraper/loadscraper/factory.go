@@ -38,7 +38,8 @@ type Factory struct {
 // CreateDefaultConfig creates the default configuration for the Scraper.
 func (f *Factory) CreateDefaultConfig() internal.Config {
 	return &Config{
-		Metrics: metadata.DefaultMetricsSettings(),
+		Metrics:            metadata.DefaultMetricsSettings(),
+		WindowsEmulateLoad: true,
 	}
 }
 
@@ -49,7 +50,10 @@ func (f *Factory) CreateMetricsScraper(
 	config internal.Config,
 ) (scraperhelper.Scraper, error) {
 	cfg := config.(*Config)
-	s := newLoadScraper(ctx, settings, cfg)
+	s, err := newLoadScraper(ctx, settings, cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	return scraperhelper.NewScraper(
 		TypeStr,
@@ -26,6 +26,7 @@ func TestCreateDefaultConfig(t *testing.T) {
 	factory := &Factory{}
 	cfg := factory.CreateDefaultConfig()
 	assert.IsType(t, &Config{}, cfg)
+	assert.True(t, cfg.(*Config).WindowsEmulateLoad)
 }
 
 func TestCreateMetricsScraper(t *testing.T) {
@@ -20,6 +20,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/host"
 	"github.com/shirou/gopsutil/v3/load"
 	"go.opentelemetry.io/collector/component"
@@ -40,15 +41,43 @@ type scraper struct {
 	config     *Config
 	mb         *metadata.MetricsBuilder
 	skipScrape bool
+	average    averageWindows
 
 	// for mocking
 	bootTime func() (uint64, error)
 	load     func() (*load.AvgStat, error)
+	cpuCount func() (int, error)
 }
 
 // newLoadScraper creates a set of Load related metrics
-func newLoadScraper(_ context.Context, settings component.ReceiverCreateSettings, cfg *Config) *scraper {
-	return &scraper{settings: settings, config: cfg, bootTime: host.BootTime, load: getSampledLoadAverages}
+func newLoadScraper(_ context.Context, settings component.ReceiverCreateSettings, cfg *Config) (*scraper, error) {
+	average, err := cfg.averageWindows()
+	if err != nil {
+		return nil, err
+	}
+	source, err := cfg.cpuCountSource()
+	if err != nil {
+		return nil, err
+	}
+	cpuCount := logicalCPUCount
+	if source == cpuCountSourceRuntime {
+		cpuCount = runtimeCPUCount
+	}
+	if cfg.CPUAverage {
+		settings.Logger.Warn("cpu_average is deprecated and will be removed in a future release, use cpu_average_windows instead")
+	}
+	return &scraper{settings: settings, config: cfg, average: average, bootTime: host.BootTime, load: getSampledLoadAverages, cpuCount: cpuCount}, nil
+}
+
+// logicalCPUCount returns the number of logical CPUs reported by the host, which follows the CPUs being
+// hotplugged, unlike the number of CPUs seen by the Go runtime.
+func logicalCPUCount() (int, error) {
+	return cpu.Counts(true)
+}
+
+// runtimeCPUCount returns the number of CPUs usable by the collector process when it started.
+func runtimeCPUCount() (int, error) {
+	return runtime.NumCPU(), nil
 }
 
 // start
@@ -59,7 +88,13 @@ func (s *scraper) start(ctx context.Context, _ component.Host) error {
 	}
 
 	s.mb = metadata.NewMetricsBuilder(s.config.Metrics, s.settings.BuildInfo, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
-	err = startSampling(ctx, s.settings.Logger)
+	if runtime.GOOS == "windows" && !s.config.WindowsEmulateLoad {
+		// The load averages are emulated by the sampler on Windows, so there is nothing to scrape without it.
+		s.settings.Logger.Info("Load average emulation is disabled on Windows, load metrics will not be scraped")
+		s.skipScrape = true
+		return nil
+	}
+	err = startSampling(ctx, s.settings.Logger, s.config.samplingInterval())
 
 	var initErr *perfcounters.PerfCounterInitError
 	switch {
@@ -98,11 +133,8 @@ func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
 		return pmetric.NewMetrics(), scrapererror.NewPartialScrapeError(err, metricsLen)
 	}
 
-	if s.config.CPUAverage {
-		divisor := float64(runtime.NumCPU())
-		avgLoadValues.Load1 /= divisor
-		avgLoadValues.Load5 /= divisor
-		avgLoadValues.Load15 /= divisor
+	if s.average != (averageWindows{}) {
+		s.divideByCPUCount(avgLoadValues)
 	}
 
 	s.mb.RecordSystemCPULoadAverage1mDataPoint(now, avgLoadValues.Load1)
@@ -111,3 +143,23 @@ func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
 
 	return s.mb.Emit(), nil
 }
+
+// divideByCPUCount divides the configured load averages by the number of CPUs, which is refreshed on each
+// scrape so that the load follows the CPUs being hotplugged.
+func (s *scraper) divideByCPUCount(avgLoadValues *load.AvgStat) {
+	numCPU, err := s.cpuCount()
+	if err != nil || numCPU <= 0 {
+		s.settings.Logger.Debug("Failed to count the logical CPUs, falling back to the CPUs seen by the runtime", zap.Error(err))
+		numCPU = runtime.NumCPU()
+	}
+	divisor := float64(numCPU)
+	if s.average.load1 {
+		avgLoadValues.Load1 /= divisor
+	}
+	if s.average.load5 {
+		avgLoadValues.Load5 /= divisor
+	}
+	if s.average.load15 {
+		avgLoadValues.Load15 /= divisor
+	}
+}
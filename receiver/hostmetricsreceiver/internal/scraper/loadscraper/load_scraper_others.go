@@ -19,13 +19,14 @@ package loadscraper // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	"time"
 
 	"github.com/shirou/gopsutil/v3/load"
 	"go.uber.org/zap"
 )
 
 // unix based systems sample & compute load averages in the kernel, so nothing to do here
-func startSampling(_ context.Context, _ *zap.Logger) error {
+func startSampling(_ context.Context, _ *zap.Logger, _ time.Duration) error {
 	return nil
 }
 
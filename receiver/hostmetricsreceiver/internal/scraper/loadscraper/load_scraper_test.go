@@ -19,6 +19,7 @@ import (
 	"errors"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/shirou/gopsutil/v3/load"
 	"github.com/stretchr/testify/assert"
@@ -83,7 +84,8 @@ func TestScrape(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
-			scraper := newLoadScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), test.config)
+			scraper, err := newLoadScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), test.config)
+			require.NoError(t, err, "Failed to create load scraper: %v", err)
 			if test.loadFunc != nil {
 				scraper.load = test.loadFunc
 			}
@@ -91,7 +93,7 @@ func TestScrape(t *testing.T) {
 				scraper.bootTime = test.bootTimeFunc
 			}
 
-			err := scraper.start(context.Background(), componenttest.NewNopHost())
+			err = scraper.start(context.Background(), componenttest.NewNopHost())
 			require.NoError(t, err, "Failed to initialize load scraper: %v", err)
 			defer func() { assert.NoError(t, scraper.shutdown(context.Background())) }()
 
@@ -141,6 +143,172 @@ func TestScrape(t *testing.T) {
 	}
 }
 
+func TestScrapeCPUAverageWindows(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   *Config
+		expected [3]bool
+	}{
+		{
+			name:   "none",
+			config: &Config{},
+		},
+		{
+			name:     "5m and 15m",
+			config:   &Config{CPUAverageWindows: []string{"5m", "15m"}},
+			expected: [3]bool{false, true, true},
+		},
+		{
+			name:     "1m",
+			config:   &Config{CPUAverageWindows: []string{"1m"}},
+			expected: [3]bool{true, false, false},
+		},
+		{
+			name:     "deprecated cpu_average",
+			config:   &Config{CPUAverage: true},
+			expected: [3]bool{true, true, true},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.Metrics = metadata.DefaultMetricsSettings()
+			scraper, err := newLoadScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), test.config)
+			require.NoError(t, err)
+			scraper.bootTime = func() (uint64, error) { return bootTime, nil }
+			scraper.load = func() (*load.AvgStat, error) { return &load.AvgStat{Load1: 2, Load5: 4, Load15: 6}, nil }
+			scraper.cpuCount = func() (int, error) { return 4, nil }
+
+			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, scraper.shutdown(context.Background())) }()
+
+			md, err := scraper.scrape(context.Background())
+			require.NoError(t, err)
+
+			values := map[string]float64{}
+			metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			for i := 0; i < metrics.Len(); i++ {
+				values[metrics.At(i).Name()] = metrics.At(i).Gauge().DataPoints().At(0).DoubleValue()
+			}
+			for i, window := range []struct {
+				name string
+				raw  float64
+			}{
+				{"system.cpu.load_average.1m", 2},
+				{"system.cpu.load_average.5m", 4},
+				{"system.cpu.load_average.15m", 6},
+			} {
+				expected := window.raw
+				if test.expected[i] {
+					expected /= 4
+				}
+				assert.Equal(t, expected, values[window.name], window.name)
+			}
+		})
+	}
+}
+
+func TestScrapeCPUCountSource(t *testing.T) {
+	testCases := []struct {
+		name     string
+		source   string
+		cpuCount func() (int, error)
+		expected float64
+	}{
+		{
+			name:     "logical by default",
+			cpuCount: func() (int, error) { return 8, nil },
+			expected: 8,
+		},
+		{
+			name:     "logical",
+			source:   "logical",
+			cpuCount: func() (int, error) { return 8, nil },
+			expected: 8,
+		},
+		{
+			name:     "runtime",
+			source:   "runtime",
+			expected: float64(runtime.NumCPU()),
+		},
+		{
+			name:     "falls back to the runtime on error",
+			cpuCount: func() (int, error) { return 0, errors.New("err1") },
+			expected: float64(runtime.NumCPU()),
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &Config{
+				Metrics:           metadata.DefaultMetricsSettings(),
+				CPUAverageWindows: []string{"1m"},
+				CPUCountSource:    test.source,
+			}
+			scraper, err := newLoadScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg)
+			require.NoError(t, err)
+			scraper.bootTime = func() (uint64, error) { return bootTime, nil }
+			scraper.load = func() (*load.AvgStat, error) { return &load.AvgStat{Load1: 16}, nil }
+			if test.cpuCount != nil {
+				scraper.cpuCount = test.cpuCount
+			}
+
+			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, scraper.shutdown(context.Background())) }()
+
+			md, err := scraper.scrape(context.Background())
+			require.NoError(t, err)
+			metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			for i := 0; i < metrics.Len(); i++ {
+				if metrics.At(i).Name() == "system.cpu.load_average.1m" {
+					assert.Equal(t, 16/test.expected, metrics.At(i).Gauge().DataPoints().At(0).DoubleValue())
+				}
+			}
+		})
+	}
+}
+
+func TestScrapeWindowsEmulateLoadIgnored(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the load emulation is only ignored on the other platforms")
+	}
+	cfg := &Config{Metrics: metadata.DefaultMetricsSettings()}
+	scraper, err := newLoadScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+	scraper.bootTime = func() (uint64, error) { return bootTime, nil }
+	scraper.load = func() (*load.AvgStat, error) { return &load.AvgStat{Load1: 1, Load5: 2, Load15: 3}, nil }
+
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, scraper.shutdown(context.Background())) }()
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, metricsLen, md.MetricCount())
+}
+
+func TestNewLoadScraperInvalidCPUCountSource(t *testing.T) {
+	_, err := newLoadScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), &Config{CPUCountSource: "cgroup"})
+	assert.EqualError(t, err, "invalid cpu_count_source \"cgroup\", must be one of logical or runtime")
+}
+
+func TestNewLoadScraperInvalidCPUAverageWindows(t *testing.T) {
+	_, err := newLoadScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), &Config{CPUAverageWindows: []string{"1m", "10m"}})
+	assert.EqualError(t, err, "invalid cpu_average_windows entry \"10m\", must be one of 1m, 5m or 15m")
+}
+
+func TestValidateSamplingInterval(t *testing.T) {
+	cfg := &Config{}
+	assert.NoError(t, cfg.ValidateSamplingInterval(time.Second))
+	assert.Equal(t, defaultSamplingInterval, cfg.samplingInterval())
+
+	cfg.SamplingInterval = 10 * time.Second
+	assert.NoError(t, cfg.ValidateSamplingInterval(time.Minute))
+	assert.Equal(t, 10*time.Second, cfg.samplingInterval())
+
+	assert.EqualError(t, cfg.ValidateSamplingInterval(5*time.Second), "\"sampling_interval\" (10s) must not be larger than the collection interval (5s)")
+
+	cfg.SamplingInterval = -time.Second
+	assert.EqualError(t, cfg.ValidateSamplingInterval(time.Minute), "\"sampling_interval\" must be positive")
+}
+
 func assertMetricHasSingleDatapoint(t *testing.T, metric pmetric.Metric, expectedName string) {
 	assert.Equal(t, expectedName, metric.Name())
 	assert.Equal(t, 1, metric.Gauge().DataPoints().Len())
@@ -29,22 +29,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/perfcounters"
 )
 
-// Sample processor queue length at a 5s frequency, and calculate exponentially weighted moving averages
-// as per https://en.wikipedia.org/wiki/Load_(computing)#Unix-style_load_calculation
+// Sample processor queue length at the sampling interval, 5s by default, and calculate exponentially weighted
+// moving averages as per https://en.wikipedia.org/wiki/Load_(computing)#Unix-style_load_calculation
 
 const (
 	system               = "System"
 	processorQueueLength = "Processor Queue Length"
 )
 
-var (
-	samplingFrequency = 5 * time.Second
-
-	loadAvgFactor1m  = 1 / math.Exp(samplingFrequency.Seconds()/time.Minute.Seconds())
-	loadAvgFactor5m  = 1 / math.Exp(samplingFrequency.Seconds()/(5*time.Minute).Seconds())
-	loadAvgFactor15m = 1 / math.Exp(samplingFrequency.Seconds()/(15*time.Minute).Seconds())
-)
-
 var (
 	scraperCount int
 	startupLock  sync.Mutex
@@ -56,13 +48,24 @@ type sampler struct {
 	done               chan struct{}
 	logger             *zap.Logger
 	perfCounterScraper perfcounters.PerfCounterScraper
+	samplingInterval   time.Duration
+	loadAvgFactor1m    float64
+	loadAvgFactor5m    float64
+	loadAvgFactor15m   float64
 	loadAvg1m          float64
 	loadAvg5m          float64
 	loadAvg15m         float64
 	lock               sync.RWMutex
 }
 
-func startSampling(_ context.Context, logger *zap.Logger) error {
+// loadAvgFactor returns the weight of the previous average when adding a sample taken after samplingInterval,
+// for an average over the given window.
+func loadAvgFactor(samplingInterval time.Duration, window time.Duration) float64 {
+	return 1 / math.Exp(samplingInterval.Seconds()/window.Seconds())
+}
+
+// startSampling starts the sampler shared by the load scrapers, sampling at the interval of the first one.
+func startSampling(_ context.Context, logger *zap.Logger, samplingInterval time.Duration) error {
 	startupLock.Lock()
 	defer startupLock.Unlock()
 
@@ -74,7 +77,7 @@ func startSampling(_ context.Context, logger *zap.Logger) error {
 	}
 
 	var err error
-	samplerInstance, err = newSampler(logger)
+	samplerInstance, err = newSampler(logger, samplingInterval)
 	if err != nil {
 		return err
 	}
@@ -83,7 +86,7 @@ func startSampling(_ context.Context, logger *zap.Logger) error {
 	return nil
 }
 
-func newSampler(logger *zap.Logger) (*sampler, error) {
+func newSampler(logger *zap.Logger, samplingInterval time.Duration) (*sampler, error) {
 	perfCounterScraper := &perfcounters.PerfLibScraper{}
 
 	if err := perfCounterScraper.Initialize(system); err != nil {
@@ -93,6 +96,10 @@ func newSampler(logger *zap.Logger) (*sampler, error) {
 	sampler := &sampler{
 		logger:             logger,
 		perfCounterScraper: perfCounterScraper,
+		samplingInterval:   samplingInterval,
+		loadAvgFactor1m:    loadAvgFactor(samplingInterval, time.Minute),
+		loadAvgFactor5m:    loadAvgFactor(samplingInterval, 5*time.Minute),
+		loadAvgFactor15m:   loadAvgFactor(samplingInterval, 15*time.Minute),
 		done:               make(chan struct{}),
 	}
 
@@ -101,7 +108,7 @@ func newSampler(logger *zap.Logger) (*sampler, error) {
 
 func (sw *sampler) startSamplingTicker() {
 	go func() {
-		ticker := time.NewTicker(samplingFrequency)
+		ticker := time.NewTicker(sw.samplingInterval)
 		defer ticker.Stop()
 
 		for {
@@ -138,9 +145,9 @@ func (sw *sampler) sampleLoad() {
 
 	sw.lock.Lock()
 	defer sw.lock.Unlock()
-	sw.loadAvg1m = sw.loadAvg1m*loadAvgFactor1m + currentLoad*(1-loadAvgFactor1m)
-	sw.loadAvg5m = sw.loadAvg5m*loadAvgFactor5m + currentLoad*(1-loadAvgFactor5m)
-	sw.loadAvg15m = sw.loadAvg15m*loadAvgFactor15m + currentLoad*(1-loadAvgFactor15m)
+	sw.loadAvg1m = sw.loadAvg1m*sw.loadAvgFactor1m + currentLoad*(1-sw.loadAvgFactor1m)
+	sw.loadAvg5m = sw.loadAvg5m*sw.loadAvgFactor5m + currentLoad*(1-sw.loadAvgFactor5m)
+	sw.loadAvg15m = sw.loadAvg15m*sw.loadAvgFactor15m + currentLoad*(1-sw.loadAvgFactor15m)
 }
 
 func stopSampling(_ context.Context) error {
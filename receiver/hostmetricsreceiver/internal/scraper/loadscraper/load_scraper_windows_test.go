@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/perfcounters"
@@ -31,11 +32,8 @@ import (
 
 func TestStartSampling(t *testing.T) {
 	t.Skip(t, "Test is causing race conditions, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10143.")
-	// override sampling frequency to 2ms
-	samplingFrequency = 2 * time.Millisecond
-
-	// startSampling should set up perf counter and start sampling
-	startSampling(context.Background(), zap.NewNop())
+	// startSampling should set up perf counter and start sampling every 2ms
+	startSampling(context.Background(), zap.NewNop(), 2*time.Millisecond)
 	assertSamplingUnderway(t)
 
 	// override the processor queue length perf counter with a mock
@@ -46,7 +44,7 @@ func TestStartSampling(t *testing.T) {
 	})
 
 	// second call to startSampling should succeed, but not do anything
-	startSampling(context.Background(), zap.NewNop())
+	startSampling(context.Background(), zap.NewNop(), 2*time.Millisecond)
 	assertSamplingUnderway(t)
 	assert.IsType(t, &perfcounters.MockPerfCounterScraper{}, samplerInstance.perfCounterScraper)
 
@@ -70,6 +68,21 @@ func TestStartSampling(t *testing.T) {
 	assertSamplingStopped(t)
 }
 
+func TestStartWithoutLoadEmulation(t *testing.T) {
+	cfg := (&Factory{}).CreateDefaultConfig().(*Config)
+	cfg.WindowsEmulateLoad = false
+	scraper, err := newLoadScraper(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	assert.True(t, scraper.skipScrape)
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.MetricCount())
+	assert.NoError(t, scraper.shutdown(context.Background()))
+}
+
 func assertSamplingUnderway(t *testing.T) {
 	assert.NotNil(t, samplerInstance)
 	assert.NotNil(t, samplerInstance.perfCounterScraper)
@@ -96,15 +109,20 @@ func TestSampleLoad(t *testing.T) {
 		system: {processorQueueLength: counterReturnValues},
 	})
 
-	samplerInstance = &sampler{perfCounterScraper: mockPerfCounterScraper}
+	samplerInstance = &sampler{
+		perfCounterScraper: mockPerfCounterScraper,
+		loadAvgFactor1m:    loadAvgFactor(defaultSamplingInterval, time.Minute),
+		loadAvgFactor5m:    loadAvgFactor(defaultSamplingInterval, 5*time.Minute),
+		loadAvgFactor15m:   loadAvgFactor(defaultSamplingInterval, 15*time.Minute),
+	}
 
 	for i := 0; i < len(counterReturnValues); i++ {
 		samplerInstance.sampleLoad()
 	}
 
-	assert.Equal(t, calcExpectedLoad(counterReturnValues, loadAvgFactor1m), samplerInstance.loadAvg1m)
-	assert.Equal(t, calcExpectedLoad(counterReturnValues, loadAvgFactor5m), samplerInstance.loadAvg5m)
-	assert.Equal(t, calcExpectedLoad(counterReturnValues, loadAvgFactor15m), samplerInstance.loadAvg15m)
+	assert.Equal(t, calcExpectedLoad(counterReturnValues, samplerInstance.loadAvgFactor1m), samplerInstance.loadAvg1m)
+	assert.Equal(t, calcExpectedLoad(counterReturnValues, samplerInstance.loadAvgFactor5m), samplerInstance.loadAvg5m)
+	assert.Equal(t, calcExpectedLoad(counterReturnValues, samplerInstance.loadAvgFactor15m), samplerInstance.loadAvg15m)
 }
 
 func calcExpectedLoad(scrapedValues []int64, loadAvgFactor float64) float64 {
@@ -118,7 +136,7 @@ func calcExpectedLoad(scrapedValues []int64, loadAvgFactor float64) float64 {
 }
 
 func Benchmark_SampleLoad(b *testing.B) {
-	s, _ := newSampler(zap.NewNop())
+	s, _ := newSampler(zap.NewNop(), defaultSamplingInterval)
 
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
@@ -90,3 +90,5 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator
 
 // see https://github.com/distribution/distribution/issues/3590
 exclude github.com/docker/distribution v2.8.0+incompatible
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl => ../../pkg/ottl
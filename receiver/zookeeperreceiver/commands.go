@@ -0,0 +1,130 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zookeeperreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zookeeperreceiver"
+
+import (
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Four letter word commands which can be sent in addition to mntr. Their output is parsed
+// into the entries of the output of mntr that they report.
+const (
+	consCommand = "cons"
+	srvrCommand = "srvr"
+	wchsCommand = "wchs"
+)
+
+// notWhitelistedSuffix ends the response of ZooKeeper to a command missing from its 4lw.commands.whitelist.
+const notWhitelistedSuffix = "is not executed because it is not in the whitelist."
+
+// entry is a key/value pair of the output of a command, named like in the output of mntr.
+type entry struct {
+	command string
+	key     string
+	value   string
+}
+
+// commandParsers parse the lines of the output of every supported command.
+var commandParsers = map[string]func(lines []string, logger *zap.Logger) []entry{
+	mntrCommand: parseMntr,
+	consCommand: parseCons,
+	srvrCommand: parseSrvr,
+	wchsCommand: parseWchs,
+}
+
+func parseMntr(lines []string, logger *zap.Logger) []entry {
+	entries := make([]entry, 0, len(lines))
+	for _, line := range lines {
+		parts := zookeeperFormatRE.FindStringSubmatch(line)
+		if len(parts) != 3 {
+			logger.Warn("unexpected line in response",
+				zap.String("command", mntrCommand),
+				zap.String("line", line),
+			)
+			continue
+		}
+		entries = append(entries, entry{command: mntrCommand, key: parts[1], value: parts[2]})
+	}
+	return entries
+}
+
+// parseCons counts the connections listed one per line.
+func parseCons(lines []string, _ *zap.Logger) []entry {
+	connections := 0
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			connections++
+		}
+	}
+	return []entry{{command: consCommand, key: numAliveConnectionsMetricKey, value: strconv.Itoa(connections)}}
+}
+
+// srvrKeys maps the fields of the output of srvr to the entries of mntr.
+var srvrKeys = map[string]string{
+	"Zookeeper version": zkVersionKey,
+	"Received":          packetsReceivedMetricKey,
+	"Sent":              packetsSentMetricKey,
+	"Connections":       numAliveConnectionsMetricKey,
+	"Outstanding":       outstandingRequestsMetricKey,
+	"Mode":              serverStateKey,
+	"Node count":        zNodeCountMetricKey,
+}
+
+func parseSrvr(lines []string, _ *zap.Logger) []entry {
+	var entries []entry
+	for _, line := range lines {
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if field == "Latency min/avg/max" {
+			latencies := strings.Split(value, "/")
+			if len(latencies) != 3 {
+				continue
+			}
+			for i, key := range []string{minLatencyMetricKey, avgLatencyMetricKey, maxLatencyMetricKey} {
+				entries = append(entries, entry{command: srvrCommand, key: key, value: latencies[i]})
+			}
+			continue
+		}
+		key, ok := srvrKeys[field]
+		if !ok {
+			continue
+		}
+		if key == zkVersionKey {
+			// the version is followed by its build date
+			value, _, _ = strings.Cut(value, ",")
+		}
+		entries = append(entries, entry{command: srvrCommand, key: key, value: value})
+	}
+	return entries
+}
+
+// totalWatchesPrefix starts the line of the output of wchs reporting the number of watches.
+const totalWatchesPrefix = "Total watches:"
+
+func parseWchs(lines []string, _ *zap.Logger) []entry {
+	for _, line := range lines {
+		if strings.HasPrefix(line, totalWatchesPrefix) {
+			value := strings.TrimSpace(strings.TrimPrefix(line, totalWatchesPrefix))
+			return []entry{{command: wchsCommand, key: watchCountMetricKey, value: value}}
+		}
+	}
+	return nil
+}
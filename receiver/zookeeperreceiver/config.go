@@ -15,9 +15,12 @@
 package zookeeperreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zookeeperreceiver"
 
 import (
+	"fmt"
+	"reflect"
 	"time"
 
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zookeeperreceiver/internal/metadata"
@@ -26,8 +29,86 @@ import (
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	confignet.TCPAddr                       `mapstructure:",squash"`
+	configtls.TLSClientSetting              `mapstructure:"tls,omitempty"`
 	Metrics                                 metadata.MetricsSettings `mapstructure:"metrics"`
 
+	// EnabledMetrics is an allowlist of the names of the metrics to record. When set, it takes
+	// precedence over Metrics, and the metrics which aren't listed are disabled.
+	EnabledMetrics []string `mapstructure:"enabled_metrics"`
+
+	// Endpoints are the endpoints of the members of an ensemble, which are scraped independently. When set, it
+	// takes precedence over Endpoint, and the metrics of each member have the `zk.server.address` resource attribute.
+	Endpoints []string `mapstructure:"endpoints"`
+
+	// Commands are the four letter word commands sent in addition to mntr, among "cons", "srvr" and "wchs", to
+	// collect the metrics that mntr doesn't report. The values reported by mntr take precedence.
+	Commands []string `mapstructure:"commands"`
+
 	// Timeout within which requests should be completed.
 	Timeout time.Duration `mapstructure:"timeout"`
 }
+
+func (c *Config) Validate() error {
+	if err := c.validateCommands(); err != nil {
+		return err
+	}
+	_, err := c.metricsSettings()
+	return err
+}
+
+func (c *Config) validateCommands() error {
+	for _, cmd := range c.Commands {
+		if _, ok := commandParsers[cmd]; !ok {
+			return fmt.Errorf("unsupported command %q in commands, must be one of %q, %q, %q or %q", cmd, consCommand, mntrCommand, srvrCommand, wchsCommand)
+		}
+	}
+	return nil
+}
+
+// commands returns the commands to send, starting with mntr, without duplicates.
+func (c *Config) commands() []string {
+	commands := []string{mntrCommand}
+	for _, cmd := range c.Commands {
+		duplicate := false
+		for _, existing := range commands {
+			duplicate = duplicate || existing == cmd
+		}
+		if !duplicate {
+			commands = append(commands, cmd)
+		}
+	}
+	return commands
+}
+
+// endpoints returns the endpoints to scrape, either Endpoints or the single Endpoint.
+func (c *Config) endpoints() []string {
+	if len(c.Endpoints) > 0 {
+		return c.Endpoints
+	}
+	return []string{c.Endpoint}
+}
+
+// metricsSettings returns the settings of the metrics to record, taking EnabledMetrics into account.
+func (c *Config) metricsSettings() (metadata.MetricsSettings, error) {
+	settings := c.Metrics
+	if len(c.EnabledMetrics) == 0 {
+		return settings, nil
+	}
+
+	// The metrics are looked up by the names used to configure them in the metrics section.
+	v := reflect.ValueOf(&settings).Elem()
+	byName := make(map[string]*metadata.MetricSettings, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		ms := v.Field(i).Addr().Interface().(*metadata.MetricSettings)
+		ms.Enabled = false
+		byName[v.Type().Field(i).Tag.Get("mapstructure")] = ms
+	}
+	for _, name := range c.EnabledMetrics {
+		ms, ok := byName[name]
+		if !ok {
+			return settings, fmt.Errorf("unknown metric %q in enabled_metrics", name)
+		}
+		ms.Enabled = true
+	}
+	return settings, nil
+}
@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 
@@ -52,6 +53,9 @@ func createDefaultConfig() config.Receiver {
 		TCPAddr: confignet.TCPAddr{
 			Endpoint: ":2181",
 		},
+		TLSClientSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
 		Timeout: defaultTimeout,
 		Metrics: metadata.DefaultMetricsSettings(),
 	}
@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 )
 
@@ -38,6 +39,7 @@ func TestFactory(t *testing.T) {
 	assert.Equal(t, 10*time.Second, rCfg.CollectionInterval)
 	assert.Equal(t, 10*time.Second, rCfg.Timeout)
 	assert.Equal(t, ":2181", rCfg.Endpoint)
+	assert.True(t, rCfg.Insecure)
 
 	tests := []struct {
 		name    string
@@ -62,6 +64,22 @@ func TestFactory(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Invalid TLS certificate",
+			config: &Config{
+				TCPAddr: confignet.TCPAddr{
+					Endpoint: ":2181",
+				},
+				TLSClientSetting: configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CertFile: "testdata/certs/missing.crt",
+						KeyFile:  "testdata/certs/missing.key",
+					},
+				},
+				Timeout: defaultTimeout,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, test := range tests {
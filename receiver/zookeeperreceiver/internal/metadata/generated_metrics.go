@@ -999,6 +999,20 @@ func WithServerState(val string) ResourceMetricsOption {
 	}
 }
 
+// WithZkServerAddress sets provided value as "zk.server.address" attribute for current resource.
+func WithZkServerAddress(val string) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		rm.Resource().Attributes().PutStr("zk.server.address", val)
+	}
+}
+
+// WithZkServerState sets provided value as "zk.server.state" attribute for current resource.
+func WithZkServerState(val string) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		rm.Resource().Attributes().PutStr("zk.server.state", val)
+	}
+}
+
 // WithZkVersion sets provided value as "zk.version" attribute for current resource.
 func WithZkVersion(val string) ResourceMetricsOption {
 	return func(rm pmetric.ResourceMetrics) {
@@ -63,64 +63,154 @@ func newMetricCreator(mb *metadata.MetricsBuilder) *metricCreator {
 	}
 }
 
-func (m *metricCreator) recordDataPointsFunc(metric string) func(ts pcommon.Timestamp, val int64) {
-	switch metric {
-	case followersMetricKey:
-		return func(ts pcommon.Timestamp, val int64) {
+// mntrMetric describes how the value of a "mntr" key is recorded.
+type mntrMetric struct {
+	// enabled returns whether the metric recorded from the key is enabled.
+	enabled func(settings metadata.MetricsSettings) bool
+	record  func(m *metricCreator, ts pcommon.Timestamp, val int64)
+}
+
+// mntrMetrics maps the "mntr" keys to the metrics recorded from their values.
+var mntrMetrics = map[string]mntrMetric{
+	followersMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperFollowerCount.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
 			m.computedMetricStore[followersMetricKey] = val
-		}
-	case syncedFollowersMetricKey:
-		return func(ts pcommon.Timestamp, val int64) {
+		},
+	},
+	syncedFollowersMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperFollowerCount.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
 			m.computedMetricStore[syncedFollowersMetricKey] = val
 			m.mb.RecordZookeeperFollowerCountDataPoint(ts, val, metadata.AttributeStateSynced)
-		}
-	case pendingSyncsMetricKey:
-		return m.mb.RecordZookeeperSyncPendingDataPoint
-	case avgLatencyMetricKey:
-		return m.mb.RecordZookeeperLatencyAvgDataPoint
-	case maxLatencyMetricKey:
-		return m.mb.RecordZookeeperLatencyMaxDataPoint
-	case minLatencyMetricKey:
-		return m.mb.RecordZookeeperLatencyMinDataPoint
-	case numAliveConnectionsMetricKey:
-		return m.mb.RecordZookeeperConnectionActiveDataPoint
-	case outstandingRequestsMetricKey:
-		return m.mb.RecordZookeeperRequestActiveDataPoint
-	case zNodeCountMetricKey:
-		return m.mb.RecordZookeeperZnodeCountDataPoint
-	case watchCountMetricKey:
-		return m.mb.RecordZookeeperWatchCountDataPoint
-	case ephemeralsCountMetricKey:
-		return m.mb.RecordZookeeperDataTreeEphemeralNodeCountDataPoint
-	case approximateDataSizeMetricKey:
-		return m.mb.RecordZookeeperDataTreeSizeDataPoint
-	case openFileDescriptorCountMetricKey:
-		return m.mb.RecordZookeeperFileDescriptorOpenDataPoint
-	case maxFileDescriptorCountMetricKey:
-		return m.mb.RecordZookeeperFileDescriptorLimitDataPoint
-	case fSyncThresholdExceedCountMetricKey:
-		return m.mb.RecordZookeeperFsyncExceededThresholdCountDataPoint
-	case packetsReceivedMetricKey:
-		return func(ts pcommon.Timestamp, val int64) {
+		},
+	},
+	pendingSyncsMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperSyncPending.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperSyncPendingDataPoint(ts, val)
+		},
+	},
+	avgLatencyMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperLatencyAvg.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperLatencyAvgDataPoint(ts, val)
+		},
+	},
+	maxLatencyMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperLatencyMax.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperLatencyMaxDataPoint(ts, val)
+		},
+	},
+	minLatencyMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperLatencyMin.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperLatencyMinDataPoint(ts, val)
+		},
+	},
+	numAliveConnectionsMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperConnectionActive.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperConnectionActiveDataPoint(ts, val)
+		},
+	},
+	outstandingRequestsMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperRequestActive.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperRequestActiveDataPoint(ts, val)
+		},
+	},
+	zNodeCountMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperZnodeCount.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperZnodeCountDataPoint(ts, val)
+		},
+	},
+	watchCountMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperWatchCount.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperWatchCountDataPoint(ts, val)
+		},
+	},
+	ephemeralsCountMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperDataTreeEphemeralNodeCount.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperDataTreeEphemeralNodeCountDataPoint(ts, val)
+		},
+	},
+	approximateDataSizeMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperDataTreeSize.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperDataTreeSizeDataPoint(ts, val)
+		},
+	},
+	openFileDescriptorCountMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperFileDescriptorOpen.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperFileDescriptorOpenDataPoint(ts, val)
+		},
+	},
+	maxFileDescriptorCountMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperFileDescriptorLimit.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperFileDescriptorLimitDataPoint(ts, val)
+		},
+	},
+	fSyncThresholdExceedCountMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperFsyncExceededThresholdCount.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
+			m.mb.RecordZookeeperFsyncExceededThresholdCountDataPoint(ts, val)
+		},
+	},
+	packetsReceivedMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperPacketCount.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
 			m.mb.RecordZookeeperPacketCountDataPoint(ts, val, metadata.AttributeDirectionReceived)
-		}
-	case packetsSentMetricKey:
-		return func(ts pcommon.Timestamp, val int64) {
+		},
+	},
+	packetsSentMetricKey: {
+		enabled: func(s metadata.MetricsSettings) bool { return s.ZookeeperPacketCount.Enabled },
+		record: func(m *metricCreator, ts pcommon.Timestamp, val int64) {
 			m.mb.RecordZookeeperPacketCountDataPoint(ts, val, metadata.AttributeDirectionSent)
-		}
+		},
+	},
+}
+
+func (m *metricCreator) recordDataPointsFunc(metric string) func(ts pcommon.Timestamp, val int64) {
+	mm, ok := mntrMetrics[metric]
+	if !ok {
+		return nil
 	}
+	return func(ts pcommon.Timestamp, val int64) {
+		mm.record(m, ts, val)
+	}
+}
 
-	return nil
+// isRecorded returns whether the value of the given "mntr" key is used to record an enabled metric.
+func isRecorded(settings metadata.MetricsSettings, metric string) bool {
+	mm, ok := mntrMetrics[metric]
+	return ok && mm.enabled(settings)
 }
 
 func (m *metricCreator) generateComputedMetrics(logger *zap.Logger, ts pcommon.Timestamp) {
-	// not_synced Followers Count
+	// not_synced Followers Count, only computed on the leader as other servers don't report followers
+	if !m.isLeader() {
+		return
+	}
 	if err := m.computeNotSyncedFollowersMetric(ts); err != nil {
 		logger.Debug("metric computation failed", zap.Error(err))
 	}
 
 }
 
+// isLeader returns whether any of the follower counts, which are only reported by the leader, was recorded.
+func (m *metricCreator) isLeader() bool {
+	_, hasFollowers := m.computedMetricStore[followersMetricKey]
+	_, hasSyncedFollowers := m.computedMetricStore[syncedFollowersMetricKey]
+	return hasFollowers || hasSyncedFollowers
+}
+
 func (m *metricCreator) computeNotSyncedFollowersMetric(ts pcommon.Timestamp) error {
 	followersTotal, ok := m.computedMetricStore[followersMetricKey]
 	if !ok {
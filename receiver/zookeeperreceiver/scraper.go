@@ -17,16 +17,20 @@ package zookeeperreceiver // import "github.com/open-telemetry/opentelemetry-col
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zookeeperreceiver/internal/metadata"
@@ -36,18 +40,33 @@ var zookeeperFormatRE = regexp.MustCompile(`(^zk_\w+)\s+([\w\.\-]+)`)
 
 const (
 	mntrCommand = "mntr"
+
+	// unixEndpointPrefix is the scheme used to connect to ZooKeeper over a unix domain socket.
+	unixEndpointPrefix = "unix://"
 )
 
 type zookeeperMetricsScraper struct {
 	logger *zap.Logger
 	config *Config
-	cancel context.CancelFunc
 	mb     *metadata.MetricsBuilder
 
+	metricsSettings metadata.MetricsSettings
+
+	// tlsConfig wraps the TCP connections when set. The unix domain sockets are never wrapped.
+	tlsConfig *tls.Config
+
+	// resolvedAddresses are the addresses dialed by the last scrape for each endpoint, to report when an endpoint
+	// resolves to another one.
+	resolvedAddresses map[string]string
+
+	// commands are sent to every endpoint on each scrape, starting with mntr.
+	commands []string
+
 	// For mocking.
 	closeConnection       func(net.Conn) error
 	setConnectionDeadline func(net.Conn, time.Time) error
 	sendCmd               func(net.Conn, string) (*bufio.Scanner, error)
+	lookupHost            func(context.Context, string) ([]string, error)
 }
 
 func (z *zookeeperMetricsScraper) Name() string {
@@ -55,46 +74,130 @@ func (z *zookeeperMetricsScraper) Name() string {
 }
 
 func newZookeeperMetricsScraper(settings component.ReceiverCreateSettings, config *Config) (*zookeeperMetricsScraper, error) {
-	_, _, err := net.SplitHostPort(config.TCPAddr.Endpoint)
-	if err != nil {
-		return nil, err
+	for _, endpoint := range config.endpoints() {
+		if socketPath, ok := unixSocketPath(endpoint); ok {
+			if socketPath == "" {
+				return nil, errors.New("unix socket endpoint must specify a path")
+			}
+		} else if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			return nil, err
+		}
 	}
 
 	if config.Timeout <= 0 {
 		return nil, errors.New("timeout must be a positive duration")
 	}
 
+	if err := config.validateCommands(); err != nil {
+		return nil, err
+	}
+
+	metricsSettings, err := config.metricsSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := config.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	z := &zookeeperMetricsScraper{
 		logger:                settings.Logger,
 		config:                config,
-		mb:                    metadata.NewMetricsBuilder(config.Metrics, settings.BuildInfo),
+		mb:                    metadata.NewMetricsBuilder(metricsSettings, settings.BuildInfo),
+		metricsSettings:       metricsSettings,
+		tlsConfig:             tlsConfig,
+		resolvedAddresses:     make(map[string]string),
+		commands:              config.commands(),
 		closeConnection:       closeConnection,
 		setConnectionDeadline: setConnectionDeadline,
 		sendCmd:               sendCmd,
+		lookupHost:            net.DefaultResolver.LookupHost,
 	}
 
 	return z, nil
 }
 
 func (z *zookeeperMetricsScraper) shutdown(_ context.Context) error {
-	if z.cancel != nil {
-		z.cancel()
-		z.cancel = nil
-	}
 	return nil
 }
 
+// scrape scrapes every endpoint independently. When only some of the endpoints can be scraped, the metrics of the
+// others are returned along with a partial scrape error, counting one failure per endpoint.
 func (z *zookeeperMetricsScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
-	var ctxWithTimeout context.Context
-	ctxWithTimeout, z.cancel = context.WithTimeout(ctx, z.config.Timeout)
+	endpoints := z.config.endpoints()
+	md := pmetric.NewMetrics()
+	var errs []error
+	for _, endpoint := range endpoints {
+		endpointMetrics, err := z.scrapeEndpoint(ctx, endpoint)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		endpointMetrics.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	}
+
+	switch {
+	case len(errs) == 0:
+		return md, nil
+	case len(errs) == len(endpoints):
+		return md, multierr.Combine(errs...)
+	default:
+		return md, scrapererror.NewPartialScrapeError(multierr.Combine(errs...), len(errs))
+	}
+}
+
+// scrapeEndpoint sends every command to endpoint, each on its own connection as ZooKeeper closes the connection
+// after answering a command. The entries parsed from mntr take precedence over the ones of the other commands, whose
+// failures are only logged. As mntr is sent first and every command has its own timeout, neither an unreachable
+// endpoint nor a slow optional command delays mntr on the other endpoints past its deadline.
+func (z *zookeeperMetricsScraper) scrapeEndpoint(ctx context.Context, endpoint string) (pmetric.Metrics, error) {
+	var entries []entry
+	seen := make(map[string]bool)
+	for _, cmd := range z.commands {
+		lines, err := z.runCommand(ctx, endpoint, cmd)
+		if err != nil {
+			if cmd == mntrCommand {
+				return pmetric.NewMetrics(), err
+			}
+			continue
+		}
+		if len(lines) == 1 && strings.HasSuffix(strings.TrimSpace(lines[0]), notWhitelistedSuffix) {
+			z.logger.Warn("command refused by the server, it must be listed in 4lw.commands.whitelist",
+				zap.String("endpoint", endpoint),
+				zap.String("command", cmd),
+			)
+			continue
+		}
+		for _, e := range commandParsers[cmd](lines, z.logger) {
+			if !seen[e.key] {
+				seen[e.key] = true
+				entries = append(entries, e)
+			}
+		}
+	}
+
+	var resourceOpts []metadata.ResourceMetricsOption
+	if len(z.config.Endpoints) > 0 {
+		resourceOpts = append(resourceOpts, metadata.WithZkServerAddress(endpoint))
+	}
+	return z.getResourceMetrics(entries, resourceOpts...), nil
+}
+
+// runCommand sends cmd to endpoint on a new connection, within the configured timeout, and returns the lines of the
+// response.
+func (z *zookeeperMetricsScraper) runCommand(ctx context.Context, endpoint, cmd string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, z.config.Timeout)
+	defer cancel()
 
-	conn, err := z.config.Dial()
+	conn, err := z.dial(ctx, endpoint)
 	if err != nil {
 		z.logger.Error("failed to establish connection",
-			zap.String("endpoint", z.config.Endpoint),
+			zap.String("endpoint", endpoint),
 			zap.Error(err),
 		)
-		return pmetric.NewMetrics(), err
+		return nil, err
 	}
 	defer func() {
 		if closeErr := z.closeConnection(conn); closeErr != nil {
@@ -102,61 +205,126 @@ func (z *zookeeperMetricsScraper) scrape(ctx context.Context) (pmetric.Metrics,
 		}
 	}()
 
-	deadline, ok := ctxWithTimeout.Deadline()
+	deadline, ok := ctx.Deadline()
 	if ok {
 		if err := z.setConnectionDeadline(conn, deadline); err != nil {
 			z.logger.Warn("failed to set deadline on connection", zap.Error(err))
 		}
 	}
 
-	return z.getResourceMetrics(conn)
-}
-
-func (z *zookeeperMetricsScraper) getResourceMetrics(conn net.Conn) (pmetric.Metrics, error) {
-	scanner, err := z.sendCmd(conn, mntrCommand)
+	scanner, err := z.sendCmd(conn, cmd)
 	if err != nil {
 		z.logger.Error("failed to send command",
 			zap.Error(err),
-			zap.String("command", mntrCommand),
+			zap.String("command", cmd),
 		)
-		return pmetric.NewMetrics(), err
+		return nil, err
 	}
-
-	creator := newMetricCreator(z.mb)
-	now := pcommon.NewTimestampFromTime(time.Now())
-	resourceOpts := make([]metadata.ResourceMetricsOption, 0, 2)
+	var lines []string
 	for scanner.Scan() {
-		line := scanner.Text()
-		parts := zookeeperFormatRE.FindStringSubmatch(line)
-		if len(parts) != 3 {
-			z.logger.Warn("unexpected line in response",
-				zap.String("command", mntrCommand),
-				zap.String("line", line),
-			)
+		lines = append(lines, scanner.Text())
+	}
+	return lines, nil
+}
+
+// dial connects to endpoint, using a unix domain socket if the endpoint has
+// the unix:// scheme and TCP otherwise. The TCP connections are secured with
+// TLS when it is configured.
+func (z *zookeeperMetricsScraper) dial(ctx context.Context, endpoint string) (net.Conn, error) {
+	if socketPath, ok := unixSocketPath(endpoint); ok {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "unix", socketPath)
+	}
+
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := z.dialTCP(ctx, endpoint, host, port)
+	if err != nil || z.tlsConfig == nil {
+		return conn, err
+	}
+	tlsConfig := z.tlsConfig
+	if tlsConfig.ServerName == "" {
+		// the certificate of the server is verified against the host of the endpoint by default
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.ServerName = host
+	}
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err = tlsConn.HandshakeContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// dialTCP connects to endpoint over TCP. The host of the endpoint is resolved on
+// every scrape, so that a new address, e.g. after a failover, is dialed without
+// restarting the receiver.
+func (z *zookeeperMetricsScraper) dialTCP(ctx context.Context, endpoint, host, port string) (net.Conn, error) {
+	var dialer net.Dialer
+	if host == "" || net.ParseIP(host) != nil {
+		return dialer.DialContext(ctx, "tcp", endpoint)
+	}
+
+	addrs, err := z.lookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		address := net.JoinHostPort(addr, port)
+		var conn net.Conn
+		if conn, err = dialer.DialContext(ctx, "tcp", address); err != nil {
 			continue
 		}
+		if previous := z.resolvedAddresses[endpoint]; address != previous {
+			if previous != "" {
+				z.logger.Info("endpoint resolved to a new address",
+					zap.String("endpoint", endpoint),
+					zap.String("previous_address", previous),
+					zap.String("address", address),
+				)
+			}
+			z.resolvedAddresses[endpoint] = address
+		}
+		return conn, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("no address found for %s", host)
+	}
+	return nil, err
+}
 
-		metricKey := parts[1]
-		metricValue := parts[2]
-		switch metricKey {
+func (z *zookeeperMetricsScraper) getResourceMetrics(entries []entry, resourceOpts ...metadata.ResourceMetricsOption) pmetric.Metrics {
+	creator := newMetricCreator(z.mb)
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for _, e := range entries {
+		switch e.key {
 		case zkVersionKey:
-			resourceOpts = append(resourceOpts, metadata.WithZkVersion(metricValue))
+			resourceOpts = append(resourceOpts, metadata.WithZkVersion(e.value))
 			continue
 		case serverStateKey:
-			resourceOpts = append(resourceOpts, metadata.WithServerState(metricValue))
+			resourceOpts = append(resourceOpts,
+				metadata.WithServerState(e.value),
+				metadata.WithZkServerState(strings.ToLower(e.value)),
+			)
 			continue
 		default:
+			// Skip metric if it isn't recorded, without parsing its value.
+			if !isRecorded(z.metricsSettings, e.key) {
+				continue
+			}
 			// Skip metric if there is no descriptor associated with it.
-			recordDataPoints := creator.recordDataPointsFunc(metricKey)
+			recordDataPoints := creator.recordDataPointsFunc(e.key)
 			if recordDataPoints == nil {
 				// Unexported metric, just move to the next line.
 				continue
 			}
-			int64Val, err := strconv.ParseInt(metricValue, 10, 64)
+			int64Val, err := strconv.ParseInt(e.value, 10, 64)
 			if err != nil {
 				z.logger.Debug(
-					fmt.Sprintf("non-integer value from %s", mntrCommand),
-					zap.String("value", metricValue),
+					fmt.Sprintf("non-integer value from %s", e.command),
+					zap.String("value", e.value),
 				)
 				continue
 			}
@@ -167,7 +335,15 @@ func (z *zookeeperMetricsScraper) getResourceMetrics(conn net.Conn) (pmetric.Met
 	// Generate computed metrics
 	creator.generateComputedMetrics(z.logger, now)
 
-	return z.mb.Emit(resourceOpts...), nil
+	return z.mb.Emit(resourceOpts...)
+}
+
+// unixSocketPath returns the socket path of endpoint and true if endpoint uses the unix:// scheme.
+func unixSocketPath(endpoint string) (string, bool) {
+	if !strings.HasPrefix(endpoint, unixEndpointPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(endpoint, unixEndpointPrefix), true
 }
 
 func closeConnection(conn net.Conn) error {
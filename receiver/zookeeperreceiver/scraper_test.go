@@ -17,18 +17,23 @@ package zookeeperreceiver
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
@@ -71,12 +76,6 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 				"server.state": "standalone",
 				"zk.version":   "3.4.14-4c25d480e66aadd371de8bd2fd8da255ac140bcf",
 			},
-			expectedLogs: []logMsg{
-				{
-					msg:   "metric computation failed",
-					level: zapcore.DebugLevel,
-				},
-			},
 			expectedNumResourceMetrics: 1,
 		},
 		{
@@ -108,10 +107,6 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 					msg:   "unexpected line in response",
 					level: zapcore.WarnLevel,
 				},
-				{
-					msg:   "metric computation failed",
-					level: zapcore.DebugLevel,
-				},
 			},
 			expectedNumResourceMetrics: 0,
 		},
@@ -123,10 +118,6 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 					msg:   "non-integer value from mntr",
 					level: zapcore.DebugLevel,
 				},
-				{
-					msg:   "metric computation failed",
-					level: zapcore.DebugLevel,
-				},
 			},
 			expectedNumResourceMetrics: 0,
 		},
@@ -138,10 +129,6 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 					msg:   "failed to set deadline on connection",
 					level: zapcore.WarnLevel,
 				},
-				{
-					msg:   "metric computation failed",
-					level: zapcore.DebugLevel,
-				},
 			},
 			expectedMetricsFilename: "error-setting-connection-deadline",
 			expectedResourceAttributes: map[string]string{
@@ -157,10 +144,6 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 			name:                         "Error closing connection",
 			mockedZKOutputSourceFilename: "mntr-3.4.14",
 			expectedLogs: []logMsg{
-				{
-					msg:   "metric computation failed",
-					level: zapcore.DebugLevel,
-				},
 				{
 					msg:   "failed to shutdown connection",
 					level: zapcore.WarnLevel,
@@ -202,12 +185,6 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 				"server.state": "standalone",
 				"zk.version":   "3.4.14-4c25d480e66aadd371de8bd2fd8da255ac140bcf",
 			},
-			expectedLogs: []logMsg{
-				{
-					msg:   "metric computation failed",
-					level: zapcore.DebugLevel,
-				},
-			},
 			expectedNumResourceMetrics: 1,
 		},
 	}
@@ -216,7 +193,7 @@ func TestZookeeperMetricsScraperScrape(t *testing.T) {
 			localAddr := testutil.GetAvailableLocalAddress(t)
 			if !tt.mockZKConnectionErr {
 				ms := mockedServer{ready: make(chan bool, 1)}
-				go ms.mockZKServer(t, localAddr, tt.mockedZKOutputSourceFilename)
+				go ms.mockZKServer(t, "tcp", localAddr, tt.mockedZKOutputSourceFilename)
 				<-ms.ready
 			}
 
@@ -282,13 +259,552 @@ func TestZookeeperShutdownBeforeScrape(t *testing.T) {
 	require.NoError(t, z.shutdown(context.Background()))
 }
 
+func TestZookeeperMetricsScraperScrapeUnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix domain sockets are not supported on windows")
+	}
+
+	socketPath := filepath.Join(t.TempDir(), "zk.sock")
+	ms := mockedServer{ready: make(chan bool, 1)}
+	go ms.mockZKServer(t, "unix", socketPath, "mntr-3.4.14")
+	<-ms.ready
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = "unix://" + socketPath
+
+	z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	actualMetrics, err := z.scrape(ctx)
+	require.NoError(t, err)
+	require.NoError(t, z.shutdown(ctx))
+
+	expectedMetrics, err := golden.ReadMetrics(filepath.Join("testdata", "scraper", "correctness-v3.4.14.json"))
+	require.NoError(t, err)
+	require.NoError(t, scrapertest.CompareMetrics(expectedMetrics, actualMetrics))
+}
+
+func TestZookeeperMetricsScraperScrapeUnixSocketCanceled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix domain sockets are not supported on windows")
+	}
+
+	socketPath := filepath.Join(t.TempDir(), "zk.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = "unix://" + socketPath
+
+	z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	// connecting to the socket is bound to the context of the scrape
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = z.scrape(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewZookeeperMetricsScraperInvalidUnixEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = "unix://"
+
+	_, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.EqualError(t, err, "unix socket endpoint must specify a path")
+}
+
+func TestZookeeperMetricsScraperScrapeResolvesEndpoint(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("listening on 127.0.0.2 requires the whole loopback range, as on linux")
+	}
+
+	_, port, err := net.SplitHostPort(testutil.GetAvailableLocalAddress(t))
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = net.JoinHostPort("zookeeper.example", port)
+
+	core, observedLogs := observer.New(zap.InfoLevel)
+	settings := componenttest.NewNopReceiverCreateSettings()
+	settings.Logger = zap.New(core)
+	z, err := newZookeeperMetricsScraper(settings, cfg)
+	require.NoError(t, err)
+
+	var lookups []string
+	resolved := "127.0.0.1"
+	z.lookupHost = func(_ context.Context, host string) ([]string, error) {
+		lookups = append(lookups, host)
+		return []string{resolved}, nil
+	}
+
+	ctx := context.Background()
+	for _, addr := range []string{"127.0.0.1", "127.0.0.2"} {
+		resolved = addr
+		ms := mockedServer{ready: make(chan bool, 1)}
+		go ms.mockZKServer(t, "tcp", net.JoinHostPort(addr, port), "mntr-3.4.14")
+		<-ms.ready
+
+		_, err = z.scrape(ctx)
+		require.NoError(t, err)
+		require.Equal(t, net.JoinHostPort(addr, port), z.resolvedAddresses[cfg.Endpoint])
+	}
+	require.NoError(t, z.shutdown(ctx))
+
+	require.Equal(t, []string{"zookeeper.example", "zookeeper.example"}, lookups)
+	require.Equal(t, 1, observedLogs.FilterMessage("endpoint resolved to a new address").Len())
+}
+
+func TestZookeeperMetricsScraperScrapeEndpoints(t *testing.T) {
+	var endpoints []string
+	for _, filename := range []string{"mntr-3.4.14", "mntr-3.5.5"} {
+		localAddr := testutil.GetAvailableLocalAddress(t)
+		ms := mockedServer{ready: make(chan bool, 1)}
+		go ms.mockZKServer(t, "tcp", localAddr, filename)
+		<-ms.ready
+		endpoints = append(endpoints, localAddr)
+	}
+	// nothing listens on the last endpoint
+	downAddr := testutil.GetAvailableLocalAddress(t)
+	endpoints = append(endpoints, downAddr)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoints = endpoints
+
+	z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	actualMetrics, err := z.scrape(ctx)
+	require.NoError(t, z.shutdown(ctx))
+
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	var partialErr scrapererror.PartialScrapeError
+	require.True(t, errors.As(err, &partialErr))
+	require.Equal(t, 1, partialErr.Failed)
+	require.ErrorContains(t, err, downAddr)
+
+	rms := actualMetrics.ResourceMetrics()
+	require.Equal(t, 2, rms.Len())
+	for i, expectedVersion := range []string{"3.4.14", "3.5.5"} {
+		attrs := rms.At(i).Resource().Attributes()
+		address, ok := attrs.Get("zk.server.address")
+		require.True(t, ok)
+		require.Equal(t, endpoints[i], address.Str())
+		version, ok := attrs.Get("zk.version")
+		require.True(t, ok)
+		require.Contains(t, version.Str(), expectedVersion)
+	}
+}
+
+func TestZookeeperMetricsScraperScrapeEndpointsAllDown(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoints = []string{testutil.GetAvailableLocalAddress(t), testutil.GetAvailableLocalAddress(t)}
+
+	z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	actualMetrics, err := z.scrape(ctx)
+	require.NoError(t, z.shutdown(ctx))
+
+	require.Error(t, err)
+	require.False(t, scrapererror.IsPartialScrapeError(err))
+	require.Equal(t, 0, actualMetrics.ResourceMetrics().Len())
+}
+
+func TestZookeeperMetricsScraperScrapeEndpointsStalled(t *testing.T) {
+	mntr, err := os.ReadFile(filepath.Join("testdata", "mntr-3.4.14"))
+	require.NoError(t, err)
+	// the first endpoint accepts the connections but never answers
+	stalled := mockZKCommandsServer(t, nil)
+	endpoint := mockZKCommandsServer(t, map[string]string{"mntr": string(mntr)})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoints = []string{stalled, endpoint}
+	cfg.Timeout = 100 * time.Millisecond
+
+	z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	actualMetrics, err := z.scrape(context.Background())
+	require.NoError(t, err)
+
+	// the second endpoint is scraped within its own timeout, once the first one timed out
+	rms := actualMetrics.ResourceMetrics()
+	require.Equal(t, 1, rms.Len())
+	address, ok := rms.At(0).Resource().Attributes().Get("zk.server.address")
+	require.True(t, ok)
+	require.Equal(t, endpoint, address.Str())
+}
+
+func TestNewZookeeperMetricsScraperInvalidEndpoints(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoints = []string{"localhost:2181", "unix://"}
+
+	_, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.EqualError(t, err, "unix socket endpoint must specify a path")
+}
+
+func TestZookeeperMetricsScraperScrapeTLS(t *testing.T) {
+	cert, err := tls.LoadX509KeyPair(filepath.Join("testdata", "certs", "server.crt"), filepath.Join("testdata", "certs", "server.key"))
+	require.NoError(t, err)
+
+	_, port, err := net.SplitHostPort(testutil.GetAvailableLocalAddress(t))
+	require.NoError(t, err)
+	ms := mockedServer{ready: make(chan bool, 1), tlsConfig: &tls.Config{Certificates: []tls.Certificate{cert}}}
+	go ms.mockZKServer(t, "tcp", net.JoinHostPort("127.0.0.1", port), "mntr-3.4.14")
+	<-ms.ready
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = net.JoinHostPort("localhost", port)
+	cfg.TLSClientSetting = configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{
+			CAFile: filepath.Join("testdata", "certs", "server.crt"),
+		},
+	}
+
+	z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+	z.lookupHost = func(context.Context, string) ([]string, error) {
+		return []string{"127.0.0.1"}, nil
+	}
+
+	ctx := context.Background()
+	actualMetrics, err := z.scrape(ctx)
+	require.NoError(t, err)
+	require.NoError(t, z.shutdown(ctx))
+
+	expectedMetrics, err := golden.ReadMetrics(filepath.Join("testdata", "scraper", "correctness-v3.4.14.json"))
+	require.NoError(t, err)
+	require.NoError(t, scrapertest.CompareMetrics(expectedMetrics, actualMetrics))
+}
+
+func TestNewZookeeperMetricsScraperInvalidTLS(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TLSClientSetting = configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{
+			CertFile: filepath.Join("testdata", "certs", "server.crt"),
+		},
+	}
+
+	_, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.Error(t, err)
+}
+
+func TestZookeeperMetricsScraperScrapeEnabledMetrics(t *testing.T) {
+	localAddr := testutil.GetAvailableLocalAddress(t)
+	ms := mockedServer{ready: make(chan bool, 1)}
+	go ms.mockZKServer(t, "tcp", localAddr, "mntr-3.5.5")
+	<-ms.ready
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.TCPAddr.Endpoint = localAddr
+	cfg.EnabledMetrics = []string{"zookeeper.latency.avg", "zookeeper.follower.count"}
+	require.NoError(t, cfg.Validate())
+
+	z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	actualMetrics, err := z.scrape(ctx)
+	require.NoError(t, err)
+	require.NoError(t, z.shutdown(ctx))
+
+	var names []string
+	metrics := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		names = append(names, metrics.At(i).Name())
+	}
+	require.ElementsMatch(t, cfg.EnabledMetrics, names)
+}
+
+func TestZookeeperMetricsScraperScrapeServerState(t *testing.T) {
+	for _, state := range []string{"leader", "follower", "observer", "standalone", "read-only"} {
+		t.Run(state, func(t *testing.T) {
+			localAddr := testutil.GetAvailableLocalAddress(t)
+			ms := mockedServer{ready: make(chan bool, 1)}
+			go ms.mockZKServer(t, "tcp", localAddr, "mntr-3.5.5")
+			<-ms.ready
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.TCPAddr.Endpoint = localAddr
+
+			z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+			require.NoError(t, err)
+			z.sendCmd = func(net.Conn, string) (*bufio.Scanner, error) {
+				mntr := fmt.Sprintf("zk_version\t3.5.5-390fe37ea45dee01bf87dc1c042b5e3dcce88653\nzk_server_state\t%s\nzk_avg_latency\t0\n", state)
+				return bufio.NewScanner(strings.NewReader(mntr)), nil
+			}
+
+			ctx := context.Background()
+			actualMetrics, err := z.scrape(ctx)
+			require.NoError(t, err)
+			require.NoError(t, z.shutdown(ctx))
+
+			require.Equal(t, 1, actualMetrics.ResourceMetrics().Len())
+			serverState, ok := actualMetrics.ResourceMetrics().At(0).Resource().Attributes().Get("zk.server.state")
+			require.True(t, ok)
+			require.Equal(t, state, serverState.Str())
+		})
+	}
+}
+
+func TestZookeeperMetricsScraperScrapeCommands(t *testing.T) {
+	endpoint := mockZKCommandsServer(t, map[string]string{
+		"mntr": "mntr is not executed because it is not in the whitelist.\n",
+		"cons": "cons is not executed because it is not in the whitelist.\n",
+		"srvr": "Zookeeper version: 3.5.5-390fe37ea45dee01bf87dc1c042b5e3dcce88653, built on 05/03/2019 12:07 GMT\n" +
+			"Latency min/avg/max: 1/2/3\n" +
+			"Received: 10\n" +
+			"Sent: 9\n" +
+			"Connections: 4\n" +
+			"Outstanding: 5\n" +
+			"Zxid: 0x2\n" +
+			"Mode: follower\n" +
+			"Node count: 6\n",
+		"wchs": "1 connections watching 2 paths\nTotal watches:7\n",
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Commands = []string{"srvr", "wchs", "cons", "srvr"}
+	require.NoError(t, cfg.Validate())
+
+	core, observedLogs := observer.New(zap.WarnLevel)
+	settings := componenttest.NewNopReceiverCreateSettings()
+	settings.Logger = zap.New(core)
+	z, err := newZookeeperMetricsScraper(settings, cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mntr", "srvr", "wchs", "cons"}, z.commands)
+
+	ctx := context.Background()
+	actualMetrics, err := z.scrape(ctx)
+	require.NoError(t, err)
+	require.NoError(t, z.shutdown(ctx))
+
+	// the commands missing from the whitelist are skipped
+	refusals := observedLogs.FilterMessage("command refused by the server, it must be listed in 4lw.commands.whitelist")
+	require.Equal(t, 2, refusals.Len())
+	require.Equal(t, "mntr", refusals.All()[0].ContextMap()["command"])
+	require.Equal(t, "cons", refusals.All()[1].ContextMap()["command"])
+
+	require.Equal(t, map[string][]int64{
+		"zookeeper.latency.min":       {1},
+		"zookeeper.latency.avg":       {2},
+		"zookeeper.latency.max":       {3},
+		"zookeeper.packet.count":      {10, 9},
+		"zookeeper.connection.active": {4},
+		"zookeeper.request.active":    {5},
+		"zookeeper.znode.count":       {6},
+		"zookeeper.watch.count":       {7},
+	}, dataPointValues(actualMetrics))
+
+	attrs := actualMetrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw()
+	require.Equal(t, "3.5.5-390fe37ea45dee01bf87dc1c042b5e3dcce88653", attrs["zk.version"])
+	require.Equal(t, "follower", attrs["zk.server.state"])
+}
+
+func TestZookeeperMetricsScraperScrapeCommandsPrecedence(t *testing.T) {
+	mntr, err := os.ReadFile(filepath.Join("testdata", "mntr-3.4.14"))
+	require.NoError(t, err)
+	endpoint := mockZKCommandsServer(t, map[string]string{
+		"mntr": string(mntr),
+		"cons": " /127.0.0.1:50000[1](queued=0,recved=1,sent=1)\n /127.0.0.1:50001[0](queued=0,recved=1,sent=0)\n\n",
+		"wchs": "0 connections watching 0 paths\nTotal watches:0\n",
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Commands = []string{"cons", "wchs"}
+
+	z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	actualMetrics, err := z.scrape(ctx)
+	require.NoError(t, err)
+	require.NoError(t, z.shutdown(ctx))
+
+	// mntr reports 1 connection, while cons lists 2
+	values := dataPointValues(actualMetrics)
+	require.Equal(t, []int64{1}, values["zookeeper.connection.active"])
+	require.Equal(t, []int64{0}, values["zookeeper.watch.count"])
+}
+
+func TestZookeeperMetricsScraperScrapeCommandsStalled(t *testing.T) {
+	// cons is never answered
+	endpoint := mockZKCommandsServer(t, map[string]string{
+		"mntr": "mntr is not executed because it is not in the whitelist.\n",
+		"wchs": "0 connections watching 0 paths\nTotal watches:3\n",
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Commands = []string{"cons", "wchs"}
+	cfg.Timeout = 100 * time.Millisecond
+
+	z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	actualMetrics, err := z.scrape(context.Background())
+	require.NoError(t, err)
+
+	// wchs is sent within its own timeout, once cons timed out
+	values := dataPointValues(actualMetrics)
+	require.Equal(t, []int64{3}, values["zookeeper.watch.count"])
+}
+
+func TestNewZookeeperMetricsScraperUnsupportedCommand(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Commands = []string{"srvr", "ruok"}
+
+	expected := `unsupported command "ruok" in commands, must be one of "cons", "mntr", "srvr" or "wchs"`
+	require.EqualError(t, cfg.Validate(), expected)
+	_, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.EqualError(t, err, expected)
+}
+
+func TestZookeeperMetricsScraperScrapeLeaderMetrics(t *testing.T) {
+	tests := []struct {
+		name                         string
+		mockedZKOutputSourceFilename string
+		expectedFollowers            map[string]int64
+		expectedPendingSyncs         []int64
+	}{
+		{
+			name:                         "leader",
+			mockedZKOutputSourceFilename: "mntr-3.5.5",
+			expectedFollowers: map[string]int64{
+				"synced":   1,
+				"unsynced": 1,
+			},
+			expectedPendingSyncs: []int64{0},
+		},
+		{
+			name:                         "standalone",
+			mockedZKOutputSourceFilename: "mntr-3.4.14",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localAddr := testutil.GetAvailableLocalAddress(t)
+			ms := mockedServer{ready: make(chan bool, 1)}
+			go ms.mockZKServer(t, "tcp", localAddr, tt.mockedZKOutputSourceFilename)
+			<-ms.ready
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.TCPAddr.Endpoint = localAddr
+
+			z, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			actualMetrics, err := z.scrape(ctx)
+			require.NoError(t, err)
+			require.NoError(t, z.shutdown(ctx))
+
+			var followers map[string]int64
+			var pendingSyncs []int64
+			metrics := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			for i := 0; i < metrics.Len(); i++ {
+				m := metrics.At(i)
+				switch m.Name() {
+				case "zookeeper.follower.count":
+					followers = map[string]int64{}
+					dps := m.Sum().DataPoints()
+					for j := 0; j < dps.Len(); j++ {
+						state, ok := dps.At(j).Attributes().Get("state")
+						require.True(t, ok)
+						followers[state.Str()] = dps.At(j).IntValue()
+					}
+				case "zookeeper.sync.pending":
+					dps := m.Sum().DataPoints()
+					for j := 0; j < dps.Len(); j++ {
+						pendingSyncs = append(pendingSyncs, dps.At(j).IntValue())
+					}
+				}
+			}
+			require.Equal(t, tt.expectedFollowers, followers)
+			require.Equal(t, tt.expectedPendingSyncs, pendingSyncs)
+		})
+	}
+}
+
+func TestNewZookeeperMetricsScraperUnknownEnabledMetric(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.EnabledMetrics = []string{"zookeeper.latency.avg", "zookeeper.unknown"}
+
+	require.EqualError(t, cfg.Validate(), `unknown metric "zookeeper.unknown" in enabled_metrics`)
+	_, err := newZookeeperMetricsScraper(componenttest.NewNopReceiverCreateSettings(), cfg)
+	require.EqualError(t, err, `unknown metric "zookeeper.unknown" in enabled_metrics`)
+}
+
+// mockZKCommandsServer answers every command with its response, on as many connections as needed,
+// and returns the address it listens on. The commands without a response are never answered.
+func mockZKCommandsServer(t *testing.T, responses map[string]string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				cmd, _ := reader.ReadString('\n')
+				response, ok := responses[strings.TrimSpace(cmd)]
+				if !ok {
+					// stall until the client gives up
+					_, _ = io.Copy(io.Discard, reader)
+					return
+				}
+				_, _ = conn.Write([]byte(response))
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// dataPointValues returns the values of the data points of every metric, by metric name.
+func dataPointValues(md pmetric.Metrics) map[string][]int64 {
+	values := map[string][]int64{}
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		var dps pmetric.NumberDataPointSlice
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			dps = m.Gauge().DataPoints()
+		case pmetric.MetricTypeSum:
+			dps = m.Sum().DataPoints()
+		}
+		for j := 0; j < dps.Len(); j++ {
+			values[m.Name()] = append(values[m.Name()], dps.At(j).IntValue())
+		}
+	}
+	return values
+}
+
 type mockedServer struct {
 	ready chan bool
+
+	// tlsConfig makes the server accept TLS connections when set.
+	tlsConfig *tls.Config
 }
 
-func (ms *mockedServer) mockZKServer(t *testing.T, endpoint string, filename string) {
-	listener, err := net.Listen("tcp", endpoint)
+func (ms *mockedServer) mockZKServer(t *testing.T, network string, endpoint string, filename string) {
+	listener, err := net.Listen(network, endpoint)
 	require.NoError(t, err)
+	if ms.tlsConfig != nil {
+		listener = tls.NewListener(listener, ms.tlsConfig)
+	}
 	defer listener.Close()
 
 	ms.ready <- true